@@ -31,3 +31,74 @@ func TestConfiguration_With(t *testing.T) {
 	// buf, _ := json.Marshal(Config)
 	t.Logf("config: %s", buf)
 }
+
+func TestRegisterLevel(t *testing.T) {
+	level := Config.GetLoggerLevel()
+	t.Cleanup(func() {
+		unregisterLevel("AUDIT")
+		Config.SetLoggerLevel(level)
+	})
+
+	audit := RegisterLevel("audit", 420, 35, 0)
+	if audit.Int() != 420 {
+		t.Errorf("audit level integer: %d", audit.Int())
+	}
+
+	Config.SetLoggerLevel(Notice)
+	if !Config.LevelOn(audit) || !Config.NoticeOn() || Config.InfoOn() {
+		t.Errorf("level state error with notice level")
+	}
+
+	Config.SetLoggerLevel(Warn)
+	if Config.LevelOn(audit) || !Config.WarnOn() {
+		t.Errorf("level state error with warn level")
+	}
+
+	for _, d := range Levels() {
+		t.Logf("level: %-8s %d %v", d.Level, d.Int, d.Color)
+	}
+}
+
+func TestRegisterLevel_Configured(t *testing.T) {
+	c := Config.(*configuration)
+	level := c.GetLoggerLevel()
+	t.Cleanup(func() {
+		unregisterLevel("REVIEW")
+		c.SetLoggerLevel(level)
+	})
+
+	// Configured
+	// before level registered.
+	c.LoggerLevel = "review"
+	c.initDefaults()
+	if c.GetLoggerLevel() != LevelDefault {
+		t.Fatalf("expect default level used, got %s", c.GetLoggerLevel())
+	}
+
+	review := RegisterLevel("review", 430)
+	if c.GetLoggerLevel() != review || !c.LevelOn(review) || c.InfoOn() {
+		t.Errorf("expect configured level resolved on register, got %s", c.GetLoggerLevel())
+	}
+}
+
+func TestReloadSampling(t *testing.T) {
+	Config.With(SamplingRules(0.5, SamplingRule{Path: "/health", Ratio: 0}))
+
+	before := Config.GetSampling()
+	if err := Config.ReloadSampling(); err != nil {
+		t.Fatalf("reload sampling: %v", err)
+	}
+
+	c := Config.GetSampling()
+	if c == before || c.GetDefaultRatio() != 1 || len(c.GetRules()) != 0 {
+		t.Errorf("sampling not reloaded from log.yaml: %v %d", c.GetDefaultRatio(), len(c.GetRules()))
+	}
+}
+
+// unregisterLevel
+// remove level registered by test.
+func unregisterLevel(level LoggerLevel) {
+	levelMutex.Lock()
+	delete(levelIntegers, level)
+	levelMutex.Unlock()
+}
@@ -19,6 +19,7 @@ import (
 	"gopkg.in/yaml.v3"
 	"os"
 	"strings"
+	"sync"
 )
 
 var (
@@ -30,33 +31,207 @@ type (
 		DebugOn() bool
 		ErrorOn() bool
 		FatalOn() bool
+		GetBaggage() BaggageConfiguration
+		GetElasticsearchLogger() ElasticsearchLoggerConfiguration
+		GetFileLogger() FileLoggerConfiguration
+		GetFluentdLogger() FluentdLoggerConfiguration
+		GetGelfLogger() GelfLoggerConfiguration
 		GetJaegerTrace() JaegerTraceConfiguration
+		GetJournaldLogger() JournaldLoggerConfiguration
+		GetKafkaLogger() KafkaLoggerConfiguration
+		GetLoggerAsync() LoggerAsyncConfiguration
+		GetLoggerCaller() bool
 		GetLoggerLevel() LoggerLevel
 		GetLoggerName() LoggerName
+		GetLokiLogger() LokiLoggerConfiguration
+		GetOpenTracingFormat() []PropagationFormat
 		GetOpenTracingSample() string
+		GetOtlpLogger() OtlpLoggerConfiguration
 		GetOpenTracingSpanId() string
 		GetOpenTracingTraceId() string
+		GetSampling() SamplingConfiguration
 		GetServiceName() string
 		GetServicePort() int
 		GetServiceVersion() string
+		GetSyslogLogger() SyslogLoggerConfiguration
+		GetTermLogger() TermLoggerConfiguration
 		GetTracerName() TracerName
 		GetTracerTopic() string
 		GetTracerWithLog() bool
 		InfoOn() bool
+		LevelOn(level LoggerLevel) bool
+		NoticeOn() bool
+		ReloadSampling() error
 		SetLoggerLevel(level LoggerLevel)
 		SetLoggerName(name LoggerName)
 		SetTracerName(name TracerName)
+		TraceOn() bool
 		WarnOn() bool
 		With(opts ...Option)
 	}
 
+	BaggageConfiguration interface {
+		GetLogFields() []string
+		GetMaxBytes() int
+		GetMaxMembers() int
+		GetSpanAttributes() []string
+	}
+
+	ElasticsearchLoggerConfiguration interface {
+		GetAddresses() []string
+		GetApiKey() string
+		GetBatchSize() int
+		GetBatchTimeout() int
+		GetIndex() string
+		GetPassword() string
+		GetRetries() int
+		GetRetryBackoff() int
+		GetTimeout() int
+		GetUsername() string
+	}
+
+	FileLoggerConfiguration interface {
+		GetAttributes() []string
+		GetBufferSize() int
+		GetCompress() bool
+		GetFlushInterval() int
+		GetFormat() LoggerFormat
+		GetMaxAge() int
+		GetMaxBackups() int
+		GetMaxSize() int
+		GetPath() string
+		GetRotateTime() RotateTime
+		GetSplitLevels() []LoggerLevel
+	}
+
+	FluentdLoggerConfiguration interface {
+		GetAddress() string
+		GetBatchSize() int
+		GetBatchTimeout() int
+		GetNetwork() string
+		GetRequireAck() bool
+		GetRetries() int
+		GetRetryBackoff() int
+		GetTag() string
+		GetTimeout() int
+	}
+
+	GelfLoggerConfiguration interface {
+		GetAddress() string
+		GetChunkSize() int
+		GetCompression() string
+		GetHost() string
+		GetNetwork() string
+		GetTimeout() int
+	}
+
 	JaegerTraceConfiguration interface {
 		GetEndpoint() string
 		GetUsername() string
 		GetPassword() string
+		GetSamplingEndpoint() string
+		GetSamplingRefresh() int
+		GetSamplingTimeout() int
+	}
+
+	JournaldLoggerConfiguration interface {
+		GetAttributes() []string
+		GetIdentifier() string
+		GetSocket() string
+	}
+
+	KafkaLoggerConfiguration interface {
+		GetAcks() int
+		GetBatchSize() int
+		GetBatchTimeout() int
+		GetBrokers() []string
+		GetClientId() string
+		GetCompression() string
+		GetRetries() int
+		GetRetryBackoff() int
+		GetTimeout() int
+		GetTopic() string
+	}
+
+	LokiLoggerConfiguration interface {
+		GetBatchSize() int
+		GetBatchTimeout() int
+		GetCompression() string
+		GetLabels() []string
+		GetPassword() string
+		GetRetries() int
+		GetRetryBackoff() int
+		GetStructuredMetadata() bool
+		GetTenantId() string
+		GetTimeout() int
+		GetUrl() string
+		GetUsername() string
+	}
+
+	LoggerAsyncConfiguration interface {
+		GetCapacity() int
+		GetEnabled() bool
+		GetPolicy() LoggerAsyncPolicy
+	}
+
+	OtlpLoggerConfiguration interface {
+		GetBatchSize() int
+		GetBatchTimeout() int
+		GetCompression() string
+		GetEncoding() OtlpEncoding
+		GetEndpoint() string
+		GetHeaders() map[string]string
+		GetRetries() int
+		GetRetryBackoff() int
+		GetTimeout() int
+	}
+
+	SyslogLoggerConfiguration interface {
+		GetAddress() string
+		GetFacility() string
+		GetFormat() SyslogFormat
+		GetFraming() SyslogFraming
+		GetHostname() string
+		GetNetwork() string
+		GetReconnectMax() int
+		GetReconnectMin() int
+		GetSdId() string
+		GetTimeout() int
+		GetTls() SyslogTlsConfiguration
+	}
+
+	SyslogTlsConfiguration interface {
+		GetCaFile() string
+		GetCertFile() string
+		GetInsecureSkipVerify() bool
+		GetKeyFile() string
+		GetServerName() string
+	}
+
+	SamplingConfiguration interface {
+		GetDefaultRateLimit() float64
+		GetDefaultRatio() float64
+		GetRules() []SamplingRule
+	}
+
+	TermLoggerConfiguration interface {
+		GetAttributes() []string
+		GetColor() ColorMode
+		GetErrorWriter() string
+		GetFormat() LoggerFormat
+		GetTemplate() string
+		GetTheme() map[LoggerLevel][]int
+		GetTimeFormat() string
+		GetTimeZone() string
+		GetWriter() string
 	}
 
 	configuration struct {
+		// OpenTracingFormat
+		// header formats of trace context. Extracted in order until
+		// one found, injected in all formats.
+		OpenTracingFormat []PropagationFormat `yaml:"open-tracing-format"`
+
 		OpenTracingSample  string `yaml:"open-tracing-sample"`
 		OpenTracingSpanId  string `yaml:"open-tracing-span-id"`
 		OpenTracingTraceId string `yaml:"open-tracing-trace-id"`
@@ -67,6 +242,15 @@ type (
 		LoggerLevel LoggerLevel `yaml:"logger-level"`
 		LoggerName  LoggerName  `yaml:"logger-name"`
 
+		// LoggerAsync
+		// push logs into a bounded queue, exporter consume in
+		// a coroutine of provider.
+		LoggerAsync *loggerAsyncConfiguration `yaml:"logger-async"`
+
+		// LoggerCaller
+		// whether to record file and line of caller.
+		LoggerCaller bool `yaml:"logger-caller"`
+
 		// TracerName
 		// config trace exporter name.
 		TracerName TracerName `yaml:"tracer-name"`
@@ -79,15 +263,468 @@ type (
 		// whether to join the log when reporting Trace.
 		TracerWithLog bool `yaml:"tracer-with-log"`
 
-		JaegerTrace *jaegerTraceConfiguration `yaml:"jaeger-trace"`
+		Baggage             *baggageConfiguration             `yaml:"baggage"`
+		ElasticsearchLogger *elasticsearchLoggerConfiguration `yaml:"elasticsearch-logger"`
+		FileLogger          *fileLoggerConfiguration          `yaml:"file-logger"`
+		FluentdLogger       *fluentdLoggerConfiguration       `yaml:"fluentd-logger"`
+		GelfLogger          *gelfLoggerConfiguration          `yaml:"gelf-logger"`
+		JaegerTrace         *jaegerTraceConfiguration         `yaml:"jaeger-trace"`
+		JournaldLogger      *journaldLoggerConfiguration      `yaml:"journald-logger"`
+		KafkaLogger         *kafkaLoggerConfiguration         `yaml:"kafka-logger"`
+		LokiLogger          *lokiLoggerConfiguration          `yaml:"loki-logger"`
+		OtlpLogger          *otlpLoggerConfiguration          `yaml:"otlp-logger"`
+		Sampling            *samplingConfiguration            `yaml:"sampling"`
+		SyslogLogger        *syslogLoggerConfiguration        `yaml:"syslog-logger"`
+		TermLogger          *termLoggerConfiguration          `yaml:"term-logger"`
+
+		traceOn, debugOn, infoOn, noticeOn, warnOn, errorOn, fatalOn bool
+
+		// pendingLevel
+		// configured level not registered on initialize, used when
+		// registered by RegisterLevel.
+		pendingLevel LoggerLevel
+
+		// samplingMutex
+		// sampling is replaced on reload.
+		samplingMutex sync.RWMutex
+	}
+
+	baggageConfiguration struct {
+		// LogFields
+		// baggage keys copied into fields of span logs.
+		LogFields []string `yaml:"log-fields"`
+
+		// MaxBytes
+		// maximum bytes of encoded baggage header, members over
+		// limit are discarded.
+		MaxBytes int `yaml:"max-bytes"`
+
+		// MaxMembers
+		// maximum count of baggage members.
+		MaxMembers int `yaml:"max-members"`
+
+		// SpanAttributes
+		// baggage keys copied into span attributes.
+		SpanAttributes []string `yaml:"span-attributes"`
+	}
+
+	elasticsearchLoggerConfiguration struct {
+		// Addresses
+		// of cluster nodes, such as http://localhost:9200. Next node
+		// used if request failed.
+		Addresses []string `yaml:"addresses"`
+
+		// ApiKey
+		// base64 encoded api key, sent as ApiKey authorization.
+		ApiKey string `yaml:"api-key"`
+
+		// BatchSize
+		// count of logs in a bulk request.
+		BatchSize int `yaml:"batch-size"`
+
+		// BatchTimeout
+		// milliseconds to wait before an incomplete batch sent.
+		BatchTimeout int `yaml:"batch-timeout"`
+
+		// Index
+		// name of index, placeholder in braces formatted with log
+		// time in UTC as go layout, such as log-trace-{2006.01.02}.
+		Index string `yaml:"index"`
+
+		// Username, Password
+		// of basic authentication, disabled if username is empty.
+		Username string `yaml:"username"`
+		Password string `yaml:"password"`
+
+		// Retries, RetryBackoff
+		// retry times and initial milliseconds between retries,
+		// backoff doubled on each retry. Only failed items retried.
+		Retries      int `yaml:"retries"`
+		RetryBackoff int `yaml:"retry-backoff"`
 
-		debugOn, infoOn, warnOn, errorOn, fatalOn bool
+		// Timeout
+		// milliseconds of a request.
+		Timeout int `yaml:"timeout"`
+	}
+
+	fileLoggerConfiguration struct {
+		// Attributes
+		// keys of provider attributes included in logfmt format,
+		// such as service.name.
+		Attributes []string `yaml:"attributes"`
+
+		// BufferSize
+		// bytes of write buffer.
+		BufferSize int `yaml:"buffer-size"`
+
+		// Compress
+		// whether to gzip rotated files.
+		Compress bool `yaml:"compress"`
+
+		// FlushInterval
+		// milliseconds between buffer flushes.
+		FlushInterval int `yaml:"flush-interval"`
+
+		// Format
+		// of log line, accepts: text, logfmt.
+		Format LoggerFormat `yaml:"format"`
+
+		// MaxAge
+		// days to retain rotated files, 0 means never remove.
+		MaxAge int `yaml:"max-age"`
+
+		// MaxBackups
+		// count of rotated files to retain, 0 means retain all.
+		MaxBackups int `yaml:"max-backups"`
+
+		// MaxSize
+		// megabytes of a file before rotation, 0 means never
+		// rotate by size.
+		MaxSize int `yaml:"max-size"`
+
+		// Path
+		// of log file.
+		Path string `yaml:"path"`
+
+		// RotateTime
+		// rotate by time, accepts: hourly, daily.
+		RotateTime RotateTime `yaml:"rotate-time"`
+
+		// SplitLevels
+		// levels also written into a separated file, named by
+		// level in same directory, such as error.log.
+		SplitLevels []LoggerLevel `yaml:"split-levels"`
+	}
+
+	journaldLoggerConfiguration struct {
+		// Attributes
+		// keys of provider attributes sent as fields, key converted
+		// to upper case, such as SERVICE_NAME.
+		Attributes []string `yaml:"attributes"`
+
+		// Identifier
+		// value of SYSLOG_IDENTIFIER, use service-name if not
+		// specified.
+		Identifier string `yaml:"identifier"`
+
+		// Socket
+		// path of journald native socket.
+		Socket string `yaml:"socket"`
+	}
+
+	kafkaLoggerConfiguration struct {
+		// Acks
+		// required acknowledgements, accepts: 0, 1, -1.
+		Acks *int `yaml:"acks"`
+
+		// BatchSize
+		// count of logs in a produce request.
+		BatchSize int `yaml:"batch-size"`
+
+		// BatchTimeout
+		// milliseconds to wait before an incomplete batch sent.
+		BatchTimeout int `yaml:"batch-timeout"`
+
+		// Brokers
+		// bootstrap broker addresses, such as localhost:9092.
+		Brokers []string `yaml:"brokers"`
+
+		ClientId string `yaml:"client-id"`
+
+		// Compression
+		// codec of record batch, accepts: none, gzip.
+		Compression string `yaml:"compression"`
+
+		// Retries, RetryBackoff
+		// retry times and milliseconds between retries.
+		Retries      int `yaml:"retries"`
+		RetryBackoff int `yaml:"retry-backoff"`
+
+		// Timeout
+		// milliseconds of a request.
+		Timeout int `yaml:"timeout"`
+
+		// Topic
+		// name of kafka topic, use tracer-topic if not specified.
+		Topic string `yaml:"topic"`
+	}
+
+	lokiLoggerConfiguration struct {
+		// BatchSize
+		// count of logs in a push request.
+		BatchSize int `yaml:"batch-size"`
+
+		// BatchTimeout
+		// milliseconds to wait before an incomplete batch sent.
+		BatchTimeout int `yaml:"batch-timeout"`
+
+		// Compression
+		// codec of request body, accepts: none, gzip.
+		Compression string `yaml:"compression"`
+
+		// Labels
+		// keys of provider attributes used as stream labels, level
+		// is the level of log. Key converted to label name, such as
+		// service.name to service_name.
+		Labels []string `yaml:"labels"`
+
+		// Username, Password
+		// of basic authentication, disabled if username is empty.
+		Username string `yaml:"username"`
+		Password string `yaml:"password"`
+
+		// Retries, RetryBackoff
+		// retry times and initial milliseconds between retries,
+		// backoff doubled on each retry. Only 429 and 5xx retried.
+		Retries      int `yaml:"retries"`
+		RetryBackoff int `yaml:"retry-backoff"`
+
+		// StructuredMetadata
+		// send trace id and span id as structured metadata, appended
+		// to log line if disabled.
+		StructuredMetadata bool `yaml:"structured-metadata"`
+
+		// TenantId
+		// value of X-Scope-OrgID header, for multi-tenant loki.
+		TenantId string `yaml:"tenant-id"`
+
+		// Timeout
+		// milliseconds of a request.
+		Timeout int `yaml:"timeout"`
+
+		// Url
+		// of loki push api.
+		Url string `yaml:"url"`
+	}
+
+	loggerAsyncConfiguration struct {
+		Capacity int               `yaml:"capacity"`
+		Enabled  bool              `yaml:"enabled"`
+		Policy   LoggerAsyncPolicy `yaml:"policy"`
+	}
+
+	otlpLoggerConfiguration struct {
+		// BatchSize
+		// count of logs in an export request.
+		BatchSize int `yaml:"batch-size"`
+
+		// BatchTimeout
+		// milliseconds to wait before an incomplete batch sent.
+		BatchTimeout int `yaml:"batch-timeout"`
+
+		// Compression
+		// codec of request body, accepts: none, gzip.
+		Compression string `yaml:"compression"`
+
+		// Encoding
+		// of request body, accepts: protobuf, json.
+		Encoding OtlpEncoding `yaml:"encoding"`
+
+		// Endpoint
+		// url of otlp/http logs api.
+		Endpoint string `yaml:"endpoint"`
+
+		// Headers
+		// sent with each request, such as authorization.
+		Headers map[string]string `yaml:"headers"`
+
+		// Retries, RetryBackoff
+		// retry times and initial milliseconds between retries,
+		// backoff doubled on each retry. Only 429, 502, 503 and 504
+		// retried.
+		Retries      int `yaml:"retries"`
+		RetryBackoff int `yaml:"retry-backoff"`
+
+		// Timeout
+		// milliseconds of a request.
+		Timeout int `yaml:"timeout"`
+	}
+
+	syslogLoggerConfiguration struct {
+		// Address
+		// of syslog server, such as localhost:514 or /dev/log.
+		Address string `yaml:"address"`
+
+		// Facility
+		// name of syslog facility, such as user, daemon, local0.
+		Facility string `yaml:"facility"`
+
+		// Format
+		// of message, accepts: rfc5424, rfc3164.
+		Format SyslogFormat `yaml:"format"`
+
+		// Framing
+		// of stream transport, accepts: octet-counting,
+		// non-transparent.
+		Framing SyslogFraming `yaml:"framing"`
+
+		// Hostname
+		// in message, use host name of os if not specified.
+		Hostname string `yaml:"hostname"`
+
+		// Network
+		// accepts: udp, tcp, tls, unix, unixgram. Local syslog
+		// socket used if not specified.
+		Network string `yaml:"network"`
+
+		// ReconnectMin, ReconnectMax
+		// milliseconds of backoff between reconnections.
+		ReconnectMax int `yaml:"reconnect-max"`
+		ReconnectMin int `yaml:"reconnect-min"`
+
+		// SdId
+		// id of structured data element in rfc5424.
+		SdId string `yaml:"sd-id"`
+
+		// Timeout
+		// milliseconds of dial and write.
+		Timeout int `yaml:"timeout"`
+
+		Tls *syslogTlsConfiguration `yaml:"tls"`
+	}
+
+	syslogTlsConfiguration struct {
+		CaFile             string `yaml:"ca-file"`
+		CertFile           string `yaml:"cert-file"`
+		InsecureSkipVerify bool   `yaml:"insecure-skip-verify"`
+		KeyFile            string `yaml:"key-file"`
+		ServerName         string `yaml:"server-name"`
+	}
+
+	termLoggerConfiguration struct {
+		// Attributes
+		// keys of provider attributes included in json and logfmt
+		// format, such as service.name.
+		Attributes []string `yaml:"attributes"`
+
+		// Color
+		// whether to print colors, accepts: auto, always, never.
+		// Colors stripped in auto mode if writer is not a terminal
+		// or NO_COLOR environment variable is set.
+		Color ColorMode `yaml:"color"`
+
+		// ErrorWriter
+		// writer for ERROR and more severe levels, accepts: stdout,
+		// stderr. Use writer if not specified.
+		ErrorWriter string `yaml:"error-writer"`
+
+		// Format
+		// of log line, accepts: text, json, logfmt.
+		Format LoggerFormat `yaml:"format"`
+
+		// Template
+		// layout of text line, accepts placeholders: {time}, {level},
+		// {msg}, {trace_id}, {span_id}, {caller}, {fields}. Width
+		// can be specified after colon, such as {level:5}.
+		Template string `yaml:"template"`
+
+		// Theme
+		// colors of levels, text color at index 0 and background
+		// color at index 1, override colors of level registration.
+		Theme map[LoggerLevel][]int `yaml:"theme"`
+
+		// TimeFormat, TimeZone
+		// layout and location of time, local time used if zone
+		// is not specified.
+		TimeFormat string `yaml:"time-format"`
+		TimeZone   string `yaml:"time-zone"`
+
+		// Writer
+		// accepts: stdout, stderr.
+		Writer string `yaml:"writer"`
+	}
+
+	fluentdLoggerConfiguration struct {
+		// Network, Address
+		// of fluentd forward input, network accepts: tcp, unix.
+		Network string `yaml:"network"`
+		Address string `yaml:"address"`
+
+		// BatchSize
+		// count of logs in a packed forward message.
+		BatchSize int `yaml:"batch-size"`
+
+		// BatchTimeout
+		// milliseconds to wait before an incomplete batch sent.
+		BatchTimeout int `yaml:"batch-timeout"`
+
+		// RequireAck
+		// wait for ack of chunk id, message resent if ack not
+		// received. Default true.
+		RequireAck *bool `yaml:"require-ack"`
+
+		// Retries, RetryBackoff
+		// retry times and initial milliseconds between retries,
+		// backoff doubled on each retry.
+		Retries      int `yaml:"retries"`
+		RetryBackoff int `yaml:"retry-backoff"`
+
+		// Tag
+		// of fluentd events, use tracer-topic if not specified.
+		Tag string `yaml:"tag"`
+
+		// Timeout
+		// milliseconds of dial, write and waiting for ack.
+		Timeout int `yaml:"timeout"`
+	}
+
+	gelfLoggerConfiguration struct {
+		// Network, Address
+		// of graylog gelf input, network accepts: udp, tcp.
+		Network string `yaml:"network"`
+		Address string `yaml:"address"`
+
+		// ChunkSize
+		// max bytes of an udp datagram, message larger than it sent
+		// in chunks.
+		ChunkSize int `yaml:"chunk-size"`
+
+		// Compression
+		// of udp message, accepts: gzip, zlib, none. Message on tcp
+		// is not compressed.
+		Compression string `yaml:"compression"`
+
+		// Host
+		// name of source, use host name of os if not specified.
+		Host string `yaml:"host"`
+
+		// Timeout
+		// milliseconds of dial and write.
+		Timeout int `yaml:"timeout"`
+	}
+
+	samplingConfiguration struct {
+		// DefaultRatio
+		// ratio of traces sampled if no rule matched, default 1.
+		DefaultRatio *float64 `yaml:"default-ratio"`
+
+		// DefaultRateLimit
+		// maximum traces sampled per second if no rule matched, used
+		// instead of default-ratio if specified.
+		DefaultRateLimit float64 `yaml:"default-rate-limit"`
+
+		// Rules
+		// evaluated in order on trace creation, first matched used.
+		Rules []SamplingRule `yaml:"rules"`
 	}
 
 	jaegerTraceConfiguration struct {
 		Endpoint string `yaml:"endpoint"`
 		Username string `yaml:"username"`
 		Password string `yaml:"password"`
+
+		// SamplingEndpoint
+		// url of sampling strategies, such as jaeger agent or
+		// collector, service name appended as query.
+		SamplingEndpoint string `yaml:"sampling-endpoint"`
+
+		// SamplingRefresh
+		// milliseconds between fetching sampling strategies.
+		SamplingRefresh int `yaml:"sampling-refresh"`
+
+		// SamplingTimeout
+		// milliseconds of a fetching request.
+		SamplingTimeout int `yaml:"sampling-timeout"`
 	}
 )
 
@@ -95,26 +732,93 @@ type (
 // Configuration: open tracing
 // /////////////////////////////////////////////////////////////////////////////
 
-func (o *configuration) DebugOn() bool                            { return o.debugOn }
-func (o *configuration) ErrorOn() bool                            { return o.errorOn }
-func (o *configuration) FatalOn() bool                            { return o.fatalOn }
-func (o *configuration) GetJaegerTrace() JaegerTraceConfiguration { return o.JaegerTrace }
-func (o *configuration) GetLoggerLevel() LoggerLevel              { return o.LoggerLevel }
-func (o *configuration) GetLoggerName() LoggerName                { return o.LoggerName }
-func (o *configuration) GetOpenTracingSample() string             { return o.OpenTracingSample }
-func (o *configuration) GetOpenTracingSpanId() string             { return o.OpenTracingSpanId }
-func (o *configuration) GetOpenTracingTraceId() string            { return o.OpenTracingTraceId }
-func (o *configuration) GetServiceName() string                   { return o.ServiceName }
-func (o *configuration) GetServicePort() int                      { return o.ServicePort }
-func (o *configuration) GetServiceVersion() string                { return o.ServiceVersion }
-func (o *configuration) GetTracerName() TracerName                { return o.TracerName }
-func (o *configuration) GetTracerTopic() string                   { return o.TracerTopic }
-func (o *configuration) GetTracerWithLog() bool                   { return o.TracerWithLog }
-func (o *configuration) InfoOn() bool                             { return o.infoOn }
-func (o *configuration) SetLoggerLevel(level LoggerLevel)         { o.LoggerLevel = level; o.resetState() }
-func (o *configuration) SetLoggerName(name LoggerName)            { o.LoggerName = name }
-func (o *configuration) SetTracerName(name TracerName)            { o.TracerName = name }
-func (o *configuration) WarnOn() bool                             { return o.warnOn }
+func (o *configuration) DebugOn() bool { return o.debugOn }
+func (o *configuration) ErrorOn() bool { return o.errorOn }
+func (o *configuration) FatalOn() bool { return o.fatalOn }
+func (o *configuration) GetBaggage() BaggageConfiguration {
+	return o.Baggage
+}
+func (o *configuration) GetElasticsearchLogger() ElasticsearchLoggerConfiguration {
+	return o.ElasticsearchLogger
+}
+func (o *configuration) GetFileLogger() FileLoggerConfiguration         { return o.FileLogger }
+func (o *configuration) GetFluentdLogger() FluentdLoggerConfiguration   { return o.FluentdLogger }
+func (o *configuration) GetGelfLogger() GelfLoggerConfiguration         { return o.GelfLogger }
+func (o *configuration) GetJaegerTrace() JaegerTraceConfiguration       { return o.JaegerTrace }
+func (o *configuration) GetJournaldLogger() JournaldLoggerConfiguration { return o.JournaldLogger }
+func (o *configuration) GetKafkaLogger() KafkaLoggerConfiguration       { return o.KafkaLogger }
+func (o *configuration) GetLoggerAsync() LoggerAsyncConfiguration       { return o.LoggerAsync }
+func (o *configuration) GetLoggerCaller() bool                          { return o.LoggerCaller }
+func (o *configuration) GetLoggerLevel() LoggerLevel                    { return o.LoggerLevel }
+func (o *configuration) GetLoggerName() LoggerName                      { return o.LoggerName }
+func (o *configuration) GetLokiLogger() LokiLoggerConfiguration         { return o.LokiLogger }
+func (o *configuration) GetOtlpLogger() OtlpLoggerConfiguration         { return o.OtlpLogger }
+func (o *configuration) GetOpenTracingFormat() []PropagationFormat      { return o.OpenTracingFormat }
+func (o *configuration) GetOpenTracingSample() string                   { return o.OpenTracingSample }
+func (o *configuration) GetOpenTracingSpanId() string                   { return o.OpenTracingSpanId }
+func (o *configuration) GetOpenTracingTraceId() string                  { return o.OpenTracingTraceId }
+func (o *configuration) GetServiceName() string                         { return o.ServiceName }
+func (o *configuration) GetServicePort() int                            { return o.ServicePort }
+func (o *configuration) GetServiceVersion() string                      { return o.ServiceVersion }
+func (o *configuration) GetSyslogLogger() SyslogLoggerConfiguration     { return o.SyslogLogger }
+func (o *configuration) GetTermLogger() TermLoggerConfiguration         { return o.TermLogger }
+func (o *configuration) GetTracerName() TracerName                      { return o.TracerName }
+func (o *configuration) GetTracerTopic() string                         { return o.TracerTopic }
+func (o *configuration) GetTracerWithLog() bool                         { return o.TracerWithLog }
+func (o *configuration) InfoOn() bool                                   { return o.infoOn }
+func (o *configuration) NoticeOn() bool                                 { return o.noticeOn }
+func (o *configuration) SetLoggerName(name LoggerName)                  { o.LoggerName = name }
+func (o *configuration) SetTracerName(name TracerName)                  { o.TracerName = name }
+func (o *configuration) TraceOn() bool                                  { return o.traceOn }
+func (o *configuration) WarnOn() bool                                   { return o.warnOn }
+
+// SetLoggerLevel
+// set level and reset level state, pending configured level is
+// discarded.
+func (o *configuration) SetLoggerLevel(level LoggerLevel) {
+	o.LoggerLevel = level
+	o.pendingLevel = ""
+	o.resetState()
+}
+
+// GetSampling
+// returns sampling configuration, replaced on reload.
+func (o *configuration) GetSampling() SamplingConfiguration {
+	o.samplingMutex.RLock()
+	defer o.samplingMutex.RUnlock()
+	return o.Sampling
+}
+
+// LevelOn
+// return true if specified level enabled, custom level supported.
+func (o *configuration) LevelOn(level LoggerLevel) bool {
+	i := level.Int()
+	c := o.LoggerLevel.Int()
+	return i > Off.Int() && c > Off.Int() && c >= i
+}
+
+// ReloadSampling
+// read sampling section of log.yaml again, rules of rule based sampler
+// are replaced.
+func (o *configuration) ReloadSampling() error {
+	for _, s := range []string{"config/log.yaml", "../config/log.yaml"} {
+		buf, err := os.ReadFile(s)
+		if err != nil {
+			continue
+		}
+
+		x := &struct {
+			Sampling *samplingConfiguration `yaml:"sampling"`
+		}{}
+		if err = yaml.Unmarshal(buf, x); err != nil {
+			return err
+		}
+
+		o.setSampling(x.Sampling)
+		return nil
+	}
+	return os.ErrNotExist
+}
 
 func (o *configuration) With(opts ...Option) {
 	for _, opt := range opts {
@@ -122,13 +826,187 @@ func (o *configuration) With(opts ...Option) {
 	}
 }
 
+// /////////////////////////////////////////////////////////////////////////////
+// Baggage Configuration
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *baggageConfiguration) GetLogFields() []string      { return o.LogFields }
+func (o *baggageConfiguration) GetMaxBytes() int            { return o.MaxBytes }
+func (o *baggageConfiguration) GetMaxMembers() int          { return o.MaxMembers }
+func (o *baggageConfiguration) GetSpanAttributes() []string { return o.SpanAttributes }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Elasticsearch Logger Configuration
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *elasticsearchLoggerConfiguration) GetAddresses() []string { return o.Addresses }
+func (o *elasticsearchLoggerConfiguration) GetApiKey() string      { return o.ApiKey }
+func (o *elasticsearchLoggerConfiguration) GetBatchSize() int      { return o.BatchSize }
+func (o *elasticsearchLoggerConfiguration) GetBatchTimeout() int   { return o.BatchTimeout }
+func (o *elasticsearchLoggerConfiguration) GetIndex() string       { return o.Index }
+func (o *elasticsearchLoggerConfiguration) GetPassword() string    { return o.Password }
+func (o *elasticsearchLoggerConfiguration) GetRetries() int        { return o.Retries }
+func (o *elasticsearchLoggerConfiguration) GetRetryBackoff() int   { return o.RetryBackoff }
+func (o *elasticsearchLoggerConfiguration) GetTimeout() int        { return o.Timeout }
+func (o *elasticsearchLoggerConfiguration) GetUsername() string    { return o.Username }
+
+// /////////////////////////////////////////////////////////////////////////////
+// File Logger Configuration
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *fileLoggerConfiguration) GetAttributes() []string       { return o.Attributes }
+func (o *fileLoggerConfiguration) GetBufferSize() int            { return o.BufferSize }
+func (o *fileLoggerConfiguration) GetCompress() bool             { return o.Compress }
+func (o *fileLoggerConfiguration) GetFlushInterval() int         { return o.FlushInterval }
+func (o *fileLoggerConfiguration) GetFormat() LoggerFormat       { return o.Format }
+func (o *fileLoggerConfiguration) GetMaxAge() int                { return o.MaxAge }
+func (o *fileLoggerConfiguration) GetMaxBackups() int            { return o.MaxBackups }
+func (o *fileLoggerConfiguration) GetMaxSize() int               { return o.MaxSize }
+func (o *fileLoggerConfiguration) GetPath() string               { return o.Path }
+func (o *fileLoggerConfiguration) GetRotateTime() RotateTime     { return o.RotateTime }
+func (o *fileLoggerConfiguration) GetSplitLevels() []LoggerLevel { return o.SplitLevels }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Fluentd Logger Configuration
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *fluentdLoggerConfiguration) GetAddress() string   { return o.Address }
+func (o *fluentdLoggerConfiguration) GetBatchSize() int    { return o.BatchSize }
+func (o *fluentdLoggerConfiguration) GetBatchTimeout() int { return o.BatchTimeout }
+func (o *fluentdLoggerConfiguration) GetNetwork() string   { return o.Network }
+func (o *fluentdLoggerConfiguration) GetRequireAck() bool  { return *o.RequireAck }
+func (o *fluentdLoggerConfiguration) GetRetries() int      { return o.Retries }
+func (o *fluentdLoggerConfiguration) GetRetryBackoff() int { return o.RetryBackoff }
+func (o *fluentdLoggerConfiguration) GetTag() string       { return o.Tag }
+func (o *fluentdLoggerConfiguration) GetTimeout() int      { return o.Timeout }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Gelf Logger Configuration
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *gelfLoggerConfiguration) GetAddress() string     { return o.Address }
+func (o *gelfLoggerConfiguration) GetChunkSize() int      { return o.ChunkSize }
+func (o *gelfLoggerConfiguration) GetCompression() string { return o.Compression }
+func (o *gelfLoggerConfiguration) GetHost() string        { return o.Host }
+func (o *gelfLoggerConfiguration) GetNetwork() string     { return o.Network }
+func (o *gelfLoggerConfiguration) GetTimeout() int        { return o.Timeout }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Journald Logger Configuration
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *journaldLoggerConfiguration) GetAttributes() []string { return o.Attributes }
+func (o *journaldLoggerConfiguration) GetIdentifier() string   { return o.Identifier }
+func (o *journaldLoggerConfiguration) GetSocket() string       { return o.Socket }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Kafka Logger Configuration
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *kafkaLoggerConfiguration) GetAcks() int           { return *o.Acks }
+func (o *kafkaLoggerConfiguration) GetBatchSize() int      { return o.BatchSize }
+func (o *kafkaLoggerConfiguration) GetBatchTimeout() int   { return o.BatchTimeout }
+func (o *kafkaLoggerConfiguration) GetBrokers() []string   { return o.Brokers }
+func (o *kafkaLoggerConfiguration) GetClientId() string    { return o.ClientId }
+func (o *kafkaLoggerConfiguration) GetCompression() string { return o.Compression }
+func (o *kafkaLoggerConfiguration) GetRetries() int        { return o.Retries }
+func (o *kafkaLoggerConfiguration) GetRetryBackoff() int   { return o.RetryBackoff }
+func (o *kafkaLoggerConfiguration) GetTimeout() int        { return o.Timeout }
+func (o *kafkaLoggerConfiguration) GetTopic() string       { return o.Topic }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Loki Logger Configuration
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *lokiLoggerConfiguration) GetBatchSize() int           { return o.BatchSize }
+func (o *lokiLoggerConfiguration) GetBatchTimeout() int        { return o.BatchTimeout }
+func (o *lokiLoggerConfiguration) GetCompression() string      { return o.Compression }
+func (o *lokiLoggerConfiguration) GetLabels() []string         { return o.Labels }
+func (o *lokiLoggerConfiguration) GetPassword() string         { return o.Password }
+func (o *lokiLoggerConfiguration) GetRetries() int             { return o.Retries }
+func (o *lokiLoggerConfiguration) GetRetryBackoff() int        { return o.RetryBackoff }
+func (o *lokiLoggerConfiguration) GetStructuredMetadata() bool { return o.StructuredMetadata }
+func (o *lokiLoggerConfiguration) GetTenantId() string         { return o.TenantId }
+func (o *lokiLoggerConfiguration) GetTimeout() int             { return o.Timeout }
+func (o *lokiLoggerConfiguration) GetUrl() string              { return o.Url }
+func (o *lokiLoggerConfiguration) GetUsername() string         { return o.Username }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Logger Async Configuration
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *loggerAsyncConfiguration) GetCapacity() int             { return o.Capacity }
+func (o *loggerAsyncConfiguration) GetEnabled() bool             { return o.Enabled }
+func (o *loggerAsyncConfiguration) GetPolicy() LoggerAsyncPolicy { return o.Policy }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Otlp Logger Configuration
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *otlpLoggerConfiguration) GetBatchSize() int             { return o.BatchSize }
+func (o *otlpLoggerConfiguration) GetBatchTimeout() int          { return o.BatchTimeout }
+func (o *otlpLoggerConfiguration) GetCompression() string        { return o.Compression }
+func (o *otlpLoggerConfiguration) GetEncoding() OtlpEncoding     { return o.Encoding }
+func (o *otlpLoggerConfiguration) GetEndpoint() string           { return o.Endpoint }
+func (o *otlpLoggerConfiguration) GetHeaders() map[string]string { return o.Headers }
+func (o *otlpLoggerConfiguration) GetRetries() int               { return o.Retries }
+func (o *otlpLoggerConfiguration) GetRetryBackoff() int          { return o.RetryBackoff }
+func (o *otlpLoggerConfiguration) GetTimeout() int               { return o.Timeout }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Sampling Configuration
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *samplingConfiguration) GetDefaultRateLimit() float64 { return o.DefaultRateLimit }
+func (o *samplingConfiguration) GetDefaultRatio() float64     { return *o.DefaultRatio }
+func (o *samplingConfiguration) GetRules() []SamplingRule     { return o.Rules }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Syslog Logger Configuration
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *syslogLoggerConfiguration) GetAddress() string             { return o.Address }
+func (o *syslogLoggerConfiguration) GetFacility() string            { return o.Facility }
+func (o *syslogLoggerConfiguration) GetFormat() SyslogFormat        { return o.Format }
+func (o *syslogLoggerConfiguration) GetFraming() SyslogFraming      { return o.Framing }
+func (o *syslogLoggerConfiguration) GetHostname() string            { return o.Hostname }
+func (o *syslogLoggerConfiguration) GetNetwork() string             { return o.Network }
+func (o *syslogLoggerConfiguration) GetReconnectMax() int           { return o.ReconnectMax }
+func (o *syslogLoggerConfiguration) GetReconnectMin() int           { return o.ReconnectMin }
+func (o *syslogLoggerConfiguration) GetSdId() string                { return o.SdId }
+func (o *syslogLoggerConfiguration) GetTimeout() int                { return o.Timeout }
+func (o *syslogLoggerConfiguration) GetTls() SyslogTlsConfiguration { return o.Tls }
+
+func (o *syslogTlsConfiguration) GetCaFile() string           { return o.CaFile }
+func (o *syslogTlsConfiguration) GetCertFile() string         { return o.CertFile }
+func (o *syslogTlsConfiguration) GetInsecureSkipVerify() bool { return o.InsecureSkipVerify }
+func (o *syslogTlsConfiguration) GetKeyFile() string          { return o.KeyFile }
+func (o *syslogTlsConfiguration) GetServerName() string       { return o.ServerName }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Term Logger Configuration
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *termLoggerConfiguration) GetAttributes() []string         { return o.Attributes }
+func (o *termLoggerConfiguration) GetColor() ColorMode             { return o.Color }
+func (o *termLoggerConfiguration) GetErrorWriter() string          { return o.ErrorWriter }
+func (o *termLoggerConfiguration) GetFormat() LoggerFormat         { return o.Format }
+func (o *termLoggerConfiguration) GetTemplate() string             { return o.Template }
+func (o *termLoggerConfiguration) GetTheme() map[LoggerLevel][]int { return o.Theme }
+func (o *termLoggerConfiguration) GetTimeFormat() string           { return o.TimeFormat }
+func (o *termLoggerConfiguration) GetTimeZone() string             { return o.TimeZone }
+func (o *termLoggerConfiguration) GetWriter() string               { return o.Writer }
+
 // /////////////////////////////////////////////////////////////////////////////
 // Jaeger Trace Configuration
 // /////////////////////////////////////////////////////////////////////////////
 
-func (o *jaegerTraceConfiguration) GetEndpoint() string { return o.Endpoint }
-func (o *jaegerTraceConfiguration) GetUsername() string { return o.Username }
-func (o *jaegerTraceConfiguration) GetPassword() string { return o.Password }
+func (o *jaegerTraceConfiguration) GetEndpoint() string         { return o.Endpoint }
+func (o *jaegerTraceConfiguration) GetUsername() string         { return o.Username }
+func (o *jaegerTraceConfiguration) GetPassword() string         { return o.Password }
+func (o *jaegerTraceConfiguration) GetSamplingEndpoint() string { return o.SamplingEndpoint }
+func (o *jaegerTraceConfiguration) GetSamplingRefresh() int     { return o.SamplingRefresh }
+func (o *jaegerTraceConfiguration) GetSamplingTimeout() int     { return o.SamplingTimeout }
 
 // /////////////////////////////////////////////////////////////////////////////
 // Access: initialize
@@ -163,6 +1041,9 @@ func (o *configuration) initDefaults() {
 	if o.OpenTracingTraceId == "" {
 		o.OpenTracingTraceId = DefaultOpenTracingTraceId
 	}
+	if len(o.OpenTracingFormat) == 0 {
+		o.OpenTracingFormat = []PropagationFormat{PropagationB3, PropagationW3c, PropagationBaggage}
+	}
 
 	// Default topic name.
 	if o.TracerTopic == "" {
@@ -170,19 +1051,108 @@ func (o *configuration) initDefaults() {
 	}
 
 	// Init
-	// log level state.
+	// log level state, default level used until configured level
+	// registered.
 	if level := LoggerLevel(strings.ToUpper(o.LoggerLevel.String())); level.Int() >= Off.Int() {
 		o.SetLoggerLevel(level)
 	} else {
 		o.SetLoggerLevel(LevelDefault)
+		o.pendingLevel = level
 	}
 }
 
 func (o *configuration) initChildren() {
+	if o.Baggage == nil {
+		o.Baggage = &baggageConfiguration{}
+	}
+	o.Baggage.initDefaults()
+
+	if o.ElasticsearchLogger == nil {
+		o.ElasticsearchLogger = &elasticsearchLoggerConfiguration{}
+	}
+	o.ElasticsearchLogger.initDefaults()
+
+	if o.FileLogger == nil {
+		o.FileLogger = &fileLoggerConfiguration{}
+	}
+	o.FileLogger.initDefaults()
+
+	if o.LoggerAsync == nil {
+		o.LoggerAsync = &loggerAsyncConfiguration{}
+	}
+	o.LoggerAsync.initDefaults()
+
 	if o.JaegerTrace == nil {
 		o.JaegerTrace = &jaegerTraceConfiguration{}
 	}
+	if o.FluentdLogger == nil {
+		o.FluentdLogger = &fluentdLoggerConfiguration{}
+	}
+	o.FluentdLogger.initDefaults(o)
+
+	if o.GelfLogger == nil {
+		o.GelfLogger = &gelfLoggerConfiguration{}
+	}
+	o.GelfLogger.initDefaults()
+
 	o.JaegerTrace.initDefaults()
+
+	if o.JournaldLogger == nil {
+		o.JournaldLogger = &journaldLoggerConfiguration{}
+	}
+	o.JournaldLogger.initDefaults(o)
+
+	if o.KafkaLogger == nil {
+		o.KafkaLogger = &kafkaLoggerConfiguration{}
+	}
+	o.KafkaLogger.initDefaults(o)
+
+	if o.LokiLogger == nil {
+		o.LokiLogger = &lokiLoggerConfiguration{}
+	}
+	o.LokiLogger.initDefaults()
+
+	if o.OtlpLogger == nil {
+		o.OtlpLogger = &otlpLoggerConfiguration{}
+	}
+	o.OtlpLogger.initDefaults()
+
+	o.setSampling(o.Sampling)
+
+	if o.SyslogLogger == nil {
+		o.SyslogLogger = &syslogLoggerConfiguration{}
+	}
+	o.SyslogLogger.initDefaults()
+
+	if o.TermLogger == nil {
+		o.TermLogger = &termLoggerConfiguration{}
+	}
+	o.TermLogger.initDefaults()
+}
+
+// setSampling
+// replace sampling with defaults initialized, sampling must not be
+// changed after set.
+func (o *configuration) setSampling(c *samplingConfiguration) {
+	if c == nil {
+		c = &samplingConfiguration{}
+	}
+	c.initDefaults()
+
+	o.samplingMutex.Lock()
+	o.Sampling = c
+	o.samplingMutex.Unlock()
+}
+
+// resolveLevel
+// use registered level if it is the pending configured level,
+// otherwise reset level state.
+func (o *configuration) resolveLevel(level LoggerLevel) {
+	if o.pendingLevel != "" && o.pendingLevel == level {
+		o.SetLoggerLevel(level)
+		return
+	}
+	o.resetState()
 }
 
 func (o *configuration) resetState() {
@@ -191,8 +1161,10 @@ func (o *configuration) resetState() {
 	is := i > Off.Int()
 
 	// Level state.
+	o.traceOn = is && i >= Trace.Int()
 	o.debugOn = is && i >= Debug.Int()
 	o.infoOn = is && i >= Info.Int()
+	o.noticeOn = is && i >= Notice.Int()
 	o.warnOn = is && i >= Warn.Int()
 	o.errorOn = is && i >= Error.Int()
 	o.fatalOn = is && i >= Fatal.Int()
@@ -202,4 +1174,290 @@ func (o *configuration) resetState() {
 // Access: initialize
 // /////////////////////////////////////////////////////////////////////////////
 
-func (o *jaegerTraceConfiguration) initDefaults() {}
+func (o *baggageConfiguration) initDefaults() {
+	if o.MaxBytes <= 0 {
+		o.MaxBytes = DefaultBaggageMaxBytes
+	}
+	if o.MaxMembers <= 0 {
+		o.MaxMembers = DefaultBaggageMaxMembers
+	}
+}
+
+func (o *elasticsearchLoggerConfiguration) initDefaults() {
+	if len(o.Addresses) == 0 {
+		o.Addresses = []string{DefaultElasticsearchLoggerAddress}
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultElasticsearchLoggerBatchSize
+	}
+	if o.BatchTimeout <= 0 {
+		o.BatchTimeout = DefaultElasticsearchLoggerBatchTimeout
+	}
+	if o.Index == "" {
+		o.Index = DefaultElasticsearchLoggerIndex
+	}
+	if o.Retries < 0 {
+		o.Retries = 0
+	}
+	if o.RetryBackoff <= 0 {
+		o.RetryBackoff = DefaultElasticsearchLoggerRetryBackoff
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultElasticsearchLoggerTimeout
+	}
+}
+
+func (o *fileLoggerConfiguration) initDefaults() {
+	if o.BufferSize <= 0 {
+		o.BufferSize = DefaultFileLoggerBufferSize
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = DefaultFileLoggerFlushInterval
+	}
+	if o.Format = LoggerFormat(strings.ToLower(o.Format.String())); o.Format == "" {
+		o.Format = FormatText
+	}
+	if o.Path == "" {
+		o.Path = DefaultFileLoggerPath
+	}
+	for i, level := range o.SplitLevels {
+		o.SplitLevels[i] = LoggerLevel(strings.ToUpper(level.String()))
+	}
+}
+
+func (o *fluentdLoggerConfiguration) initDefaults(c *configuration) {
+	if o.Address == "" {
+		o.Address = DefaultFluentdLoggerAddress
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultFluentdLoggerBatchSize
+	}
+	if o.BatchTimeout <= 0 {
+		o.BatchTimeout = DefaultFluentdLoggerBatchTimeout
+	}
+	if o.Network = strings.ToLower(o.Network); o.Network == "" {
+		o.Network = "tcp"
+	}
+	if o.RequireAck == nil {
+		b := true
+		o.RequireAck = &b
+	}
+	if o.Retries < 0 {
+		o.Retries = 0
+	}
+	if o.RetryBackoff <= 0 {
+		o.RetryBackoff = DefaultFluentdLoggerRetryBackoff
+	}
+	if o.Tag == "" {
+		o.Tag = c.TracerTopic
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultFluentdLoggerTimeout
+	}
+}
+
+func (o *gelfLoggerConfiguration) initDefaults() {
+	if o.Address == "" {
+		o.Address = DefaultGelfLoggerAddress
+	}
+	if o.ChunkSize <= 0 {
+		o.ChunkSize = DefaultGelfLoggerChunkSize
+	}
+	if o.Compression = strings.ToLower(o.Compression); o.Compression == "" {
+		o.Compression = "gzip"
+	}
+	if o.Host == "" {
+		o.Host, _ = os.Hostname()
+	}
+	if o.Network = strings.ToLower(o.Network); o.Network == "" {
+		o.Network = "udp"
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultGelfLoggerTimeout
+	}
+}
+
+func (o *journaldLoggerConfiguration) initDefaults(c *configuration) {
+	if o.Attributes == nil {
+		o.Attributes = []string{"service.name", "service.version"}
+	}
+	if o.Identifier == "" {
+		o.Identifier = c.ServiceName
+	}
+	if o.Socket == "" {
+		o.Socket = DefaultJournaldSocket
+	}
+}
+
+func (o *kafkaLoggerConfiguration) initDefaults(c *configuration) {
+	if o.Acks == nil {
+		acks := DefaultKafkaLoggerAcks
+		o.Acks = &acks
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultKafkaLoggerBatchSize
+	}
+	if o.BatchTimeout <= 0 {
+		o.BatchTimeout = DefaultKafkaLoggerBatchTimeout
+	}
+	if o.ClientId == "" {
+		o.ClientId = DefaultKafkaLoggerClientId
+	}
+	if o.Compression = strings.ToLower(o.Compression); o.Compression == "" {
+		o.Compression = "none"
+	}
+	if o.Retries < 0 {
+		o.Retries = 0
+	}
+	if o.RetryBackoff <= 0 {
+		o.RetryBackoff = DefaultKafkaLoggerRetryBackoff
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultKafkaLoggerTimeout
+	}
+	if o.Topic == "" {
+		o.Topic = c.TracerTopic
+	}
+}
+
+func (o *lokiLoggerConfiguration) initDefaults() {
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultLokiLoggerBatchSize
+	}
+	if o.BatchTimeout <= 0 {
+		o.BatchTimeout = DefaultLokiLoggerBatchTimeout
+	}
+	if o.Compression = strings.ToLower(o.Compression); o.Compression == "" {
+		o.Compression = "none"
+	}
+	if o.Labels == nil {
+		o.Labels = []string{"service.name", "runtime.host", "level"}
+	}
+	if o.Retries < 0 {
+		o.Retries = 0
+	}
+	if o.RetryBackoff <= 0 {
+		o.RetryBackoff = DefaultLokiLoggerRetryBackoff
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultLokiLoggerTimeout
+	}
+	if o.Url == "" {
+		o.Url = DefaultLokiLoggerUrl
+	}
+}
+
+func (o *loggerAsyncConfiguration) initDefaults() {
+	if o.Capacity <= 0 {
+		o.Capacity = DefaultLoggerAsyncCapacity
+	}
+	if o.Policy = LoggerAsyncPolicy(strings.ToLower(o.Policy.String())); !o.Policy.Valid() {
+		o.Policy = DefaultLoggerAsyncPolicy
+	}
+}
+
+func (o *otlpLoggerConfiguration) initDefaults() {
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultOtlpLoggerBatchSize
+	}
+	if o.BatchTimeout <= 0 {
+		o.BatchTimeout = DefaultOtlpLoggerBatchTimeout
+	}
+	if o.Compression = strings.ToLower(o.Compression); o.Compression == "" {
+		o.Compression = "none"
+	}
+	if o.Encoding = OtlpEncoding(strings.ToLower(string(o.Encoding))); o.Encoding != OtlpJson {
+		o.Encoding = OtlpProtobuf
+	}
+	if o.Endpoint == "" {
+		o.Endpoint = DefaultOtlpLoggerEndpoint
+	}
+	if o.Retries < 0 {
+		o.Retries = 0
+	}
+	if o.RetryBackoff <= 0 {
+		o.RetryBackoff = DefaultOtlpLoggerRetryBackoff
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultOtlpLoggerTimeout
+	}
+}
+
+func (o *samplingConfiguration) initDefaults() {
+	if o.DefaultRatio == nil {
+		r := DefaultSamplingRatio
+		o.DefaultRatio = &r
+	}
+}
+
+func (o *syslogLoggerConfiguration) initDefaults() {
+	if o.Facility = strings.ToLower(o.Facility); o.Facility == "" {
+		o.Facility = "user"
+	}
+	if o.Format = SyslogFormat(strings.ToLower(string(o.Format))); o.Format == "" {
+		o.Format = SyslogRfc5424
+	}
+	if o.Framing = SyslogFraming(strings.ToLower(string(o.Framing))); o.Framing == "" {
+		o.Framing = SyslogOctetCounting
+	}
+	if o.Hostname == "" {
+		o.Hostname, _ = os.Hostname()
+	}
+	o.Network = strings.ToLower(o.Network)
+	if o.ReconnectMin <= 0 {
+		o.ReconnectMin = DefaultSyslogReconnectMin
+	}
+	if o.ReconnectMax < o.ReconnectMin {
+		o.ReconnectMax = DefaultSyslogReconnectMax
+	}
+	if o.SdId == "" {
+		o.SdId = DefaultSyslogSdId
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultSyslogTimeout
+	}
+	if o.Tls == nil {
+		o.Tls = &syslogTlsConfiguration{}
+	}
+}
+
+func (o *termLoggerConfiguration) initDefaults() {
+	if o.Attributes == nil {
+		o.Attributes = []string{"service.name", "service.version"}
+	}
+	if o.Color = ColorMode(strings.ToLower(string(o.Color))); o.Color == "" {
+		o.Color = ColorAuto
+	}
+	if o.Format = LoggerFormat(strings.ToLower(o.Format.String())); o.Format == "" {
+		o.Format = FormatText
+	}
+	if o.Template == "" {
+		o.Template = DefaultTermLoggerTemplate
+	}
+	if o.TimeFormat == "" {
+		o.TimeFormat = DefaultTermLoggerTimeFormat
+	}
+	if o.Writer == "" {
+		o.Writer = WriterStdout
+	}
+
+	// Upper case
+	// level names of theme.
+	theme := make(map[LoggerLevel][]int)
+	for level, color := range o.Theme {
+		theme[LoggerLevel(strings.ToUpper(level.String()))] = color
+	}
+	o.Theme = theme
+}
+
+func (o *jaegerTraceConfiguration) initDefaults() {
+	if o.SamplingEndpoint == "" {
+		o.SamplingEndpoint = DefaultJaegerSamplingEndpoint
+	}
+	if o.SamplingRefresh <= 0 {
+		o.SamplingRefresh = DefaultJaegerSamplingRefresh
+	}
+	if o.SamplingTimeout <= 0 {
+		o.SamplingTimeout = DefaultJaegerSamplingTimeout
+	}
+}
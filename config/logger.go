@@ -15,45 +15,315 @@
 
 package config
 
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
 type (
 	LoggerLevel string
 
+	// LoggerAsyncPolicy
+	// policy of async logger when queue is full.
+	LoggerAsyncPolicy string
+
+	// ColorMode
+	// whether to print colors on terminal.
+	ColorMode string
+
+	// LoggerFormat
+	// format of log line.
+	LoggerFormat string
+
 	// LoggerName
 	// name of logger exporter.
 	LoggerName string
+
+	// OtlpEncoding
+	// encoding of otlp/http request body.
+	OtlpEncoding string
+
+	// RotateTime
+	// rotation period of file logger.
+	RotateTime string
+
+	// SyslogFormat
+	// message format of syslog protocol.
+	SyslogFormat string
+
+	// SyslogFraming
+	// message framing of syslog over stream transport.
+	SyslogFraming string
+
+	// LevelDefinition
+	// definition of a registered level.
+	LevelDefinition struct {
+		// Level
+		// name of the level, upper case.
+		Level LoggerLevel
+
+		// Int
+		// ordering of the level, a level with a greater integer
+		// is more verbose. Off is the lowest.
+		Int int
+
+		// Color
+		// terminal colors, text color at index 0 and background
+		// color at index 1.
+		Color []int
+	}
 )
 
 const (
-	Off   LoggerLevel = "OFF"
-	Fatal LoggerLevel = "FATAL"
-	Error LoggerLevel = "ERROR"
-	Warn  LoggerLevel = "WARN"
-	Info  LoggerLevel = "INFO"
-	Debug LoggerLevel = "DEBUG"
+	Off    LoggerLevel = "OFF"
+	Fatal  LoggerLevel = "FATAL"
+	Error  LoggerLevel = "ERROR"
+	Warn   LoggerLevel = "WARN"
+	Notice LoggerLevel = "NOTICE"
+	Info   LoggerLevel = "INFO"
+	Debug  LoggerLevel = "DEBUG"
+	Trace  LoggerLevel = "TRACE"
 
 	LevelDefault = Info
 )
 
 const (
-	LoggerTerm  LoggerName = "term"
-	LoggerFile  LoggerName = "file"
-	LoggerKafka LoggerName = "kafka"
+	// AsyncBlock
+	// wait until queue has free space.
+	AsyncBlock LoggerAsyncPolicy = "block"
+
+	// AsyncDropNewest
+	// discard the incoming log.
+	AsyncDropNewest LoggerAsyncPolicy = "drop-newest"
+
+	// AsyncDropOldest
+	// discard the oldest log in queue.
+	AsyncDropOldest LoggerAsyncPolicy = "drop-oldest"
+
+	// AsyncKeepError
+	// discard incoming log lower than ERROR, discard the oldest
+	// log lower than ERROR for ERROR+, otherwise wait.
+	AsyncKeepError LoggerAsyncPolicy = "keep-error"
+
+	DefaultLoggerAsyncCapacity = 1024
+	DefaultLoggerAsyncPolicy   = AsyncBlock
+)
+
+const (
+	RotateHourly RotateTime = "hourly"
+	RotateDaily  RotateTime = "daily"
+
+	DefaultFileLoggerBufferSize    = 65536
+	DefaultFileLoggerFlushInterval = 1000
+	DefaultFileLoggerPath          = "logs/app.log"
+)
+
+const (
+	DefaultElasticsearchLoggerAddress      = "http://localhost:9200"
+	DefaultElasticsearchLoggerBatchSize    = 500
+	DefaultElasticsearchLoggerBatchTimeout = 1000
+	DefaultElasticsearchLoggerIndex        = "log-trace-{2006.01.02}"
+	DefaultElasticsearchLoggerRetryBackoff = 500
+	DefaultElasticsearchLoggerTimeout      = 10000
+)
+
+const (
+	DefaultFluentdLoggerAddress      = "localhost:24224"
+	DefaultFluentdLoggerBatchSize    = 100
+	DefaultFluentdLoggerBatchTimeout = 1000
+	DefaultFluentdLoggerRetryBackoff = 500
+	DefaultFluentdLoggerTimeout      = 5000
+)
+
+const (
+	DefaultGelfLoggerAddress   = "localhost:12201"
+	DefaultGelfLoggerChunkSize = 1420
+	DefaultGelfLoggerTimeout   = 3000
+)
+
+const (
+	DefaultJournaldSocket = "/run/systemd/journal/socket"
+)
+
+const (
+	DefaultKafkaLoggerAcks         = 1
+	DefaultKafkaLoggerBatchSize    = 100
+	DefaultKafkaLoggerBatchTimeout = 1000
+	DefaultKafkaLoggerClientId     = "log-trace"
+	DefaultKafkaLoggerRetryBackoff = 100
+	DefaultKafkaLoggerTimeout      = 10000
+)
+
+const (
+	DefaultLokiLoggerBatchSize    = 100
+	DefaultLokiLoggerBatchTimeout = 1000
+	DefaultLokiLoggerRetryBackoff = 500
+	DefaultLokiLoggerTimeout      = 10000
+	DefaultLokiLoggerUrl          = "http://localhost:3100/loki/api/v1/push"
+)
+
+const (
+	OtlpJson     OtlpEncoding = "json"
+	OtlpProtobuf OtlpEncoding = "protobuf"
+
+	DefaultOtlpLoggerBatchSize    = 512
+	DefaultOtlpLoggerBatchTimeout = 1000
+	DefaultOtlpLoggerEndpoint     = "http://localhost:4318/v1/logs"
+	DefaultOtlpLoggerRetryBackoff = 500
+	DefaultOtlpLoggerTimeout      = 10000
+)
+
+const (
+	ColorAlways ColorMode = "always"
+	ColorAuto   ColorMode = "auto"
+	ColorNever  ColorMode = "never"
+
+	FormatJson   LoggerFormat = "json"
+	FormatLogfmt LoggerFormat = "logfmt"
+	FormatText   LoggerFormat = "text"
+
+	WriterStderr = "stderr"
+	WriterStdout = "stdout"
+
+	DefaultTermLoggerTemplate   = "[{time:-15}][{level:5}] {msg}"
+	DefaultTermLoggerTimeFormat = "15:04:05.999999"
+)
+
+const (
+	SyslogRfc3164 SyslogFormat = "rfc3164"
+	SyslogRfc5424 SyslogFormat = "rfc5424"
+
+	SyslogNonTransparent SyslogFraming = "non-transparent"
+	SyslogOctetCounting  SyslogFraming = "octet-counting"
+
+	DefaultSyslogReconnectMax = 10000
+	DefaultSyslogReconnectMin = 100
+	DefaultSyslogSdId         = "log@32473"
+	DefaultSyslogTimeout      = 3000
+)
+
+const (
+	LoggerTerm          LoggerName = "term"
+	LoggerElasticsearch LoggerName = "elasticsearch"
+	LoggerFile          LoggerName = "file"
+	LoggerFluentd       LoggerName = "fluentd"
+	LoggerGelf          LoggerName = "gelf"
+	LoggerJournald      LoggerName = "journald"
+	LoggerKafka         LoggerName = "kafka"
+	LoggerLoki          LoggerName = "loki"
+	LoggerOtlp          LoggerName = "otlp"
+	LoggerSyslog        LoggerName = "syslog"
 )
 
 var (
-	levelIntegers = map[LoggerLevel]int{
-		Off:   1,
-		Fatal: 2,
-		Error: 3,
-		Warn:  4,
-		Info:  5,
-		Debug: 6,
+	// levelIntegers
+	// builtin levels, gaps between integers are reserved for
+	// custom levels.
+	levelIntegers = map[LoggerLevel]*LevelDefinition{
+		Off:    {Level: Off, Int: 100},
+		Fatal:  {Level: Fatal, Int: 200, Color: []int{33, 41}}, // Text: yellow, Background: red
+		Error:  {Level: Error, Int: 300, Color: []int{31, 0}},  // Text: red, Background: white
+		Warn:   {Level: Warn, Int: 400, Color: []int{33, 0}},   // Text: yellow, Background: white
+		Notice: {Level: Notice, Int: 450, Color: []int{32, 0}}, // Text: green, Background: white
+		Info:   {Level: Info, Int: 500, Color: []int{34, 0}},   // Text: blue, Background: white
+		Debug:  {Level: Debug, Int: 600, Color: []int{37, 0}},  // Text: gray, Background: white
+		Trace:  {Level: Trace, Int: 700, Color: []int{90, 0}},  // Text: dark gray, Background: white
 	}
+
+	// levelMutex
+	// guard levelIntegers, level state of configuration is not
+	// guarded and reset on registering.
+	levelMutex = &sync.RWMutex{}
 )
 
+// Levels
+// returns registered levels ordered by integer.
+func Levels() []LevelDefinition {
+	levelMutex.RLock()
+	defer levelMutex.RUnlock()
+
+	list := make([]LevelDefinition, 0, len(levelIntegers))
+	for _, d := range levelIntegers {
+		list = append(list, *d)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Int < list[j].Int })
+	return list
+}
+
+// RegisterLevel
+// register a custom level or override a registered level. Integer must
+// be greater than Off, colors are optional as text and background.
+//
+// Must be called on initialize such as init() of main package, before
+// any log sent. Level state of configuration is reset without lock,
+// register while logging is a data race.
+//
+//	func init() {
+//	    config.RegisterLevel("AUDIT", 420, 35, 0)
+//	}
+func RegisterLevel(level LoggerLevel, i int, color ...int) LoggerLevel {
+	level = LoggerLevel(strings.ToUpper(level.String()))
+
+	// Ignore
+	// reserved Off level or invalid integer.
+	if level == Off || i <= Off.Int() {
+		return level
+	}
+
+	levelMutex.Lock()
+	levelIntegers[level] = &LevelDefinition{Level: level, Int: i, Color: color}
+	levelMutex.Unlock()
+
+	// Resolve
+	// configured level or reset level state of configuration.
+	if c, ok := Config.(*configuration); ok && c != nil {
+		c.resolveLevel(level)
+	}
+	return level
+}
+
+func (o LoggerFormat) String() string {
+	return string(o)
+}
+
+func (o LoggerAsyncPolicy) String() string {
+	return string(o)
+}
+
+// Valid
+// return true if policy is builtin.
+func (o LoggerAsyncPolicy) Valid() bool {
+	switch o {
+	case AsyncBlock, AsyncDropNewest, AsyncDropOldest, AsyncKeepError:
+		return true
+	}
+	return false
+}
+
+// Color
+// returns terminal colors of level, text color at index 0 and
+// background color at index 1.
+func (o LoggerLevel) Color() (color []int, ok bool) {
+	levelMutex.RLock()
+	defer levelMutex.RUnlock()
+
+	if d, exists := levelIntegers[o]; exists && len(d.Color) > 0 {
+		color = []int{d.Color[0], 0}
+		if len(d.Color) > 1 {
+			color[1] = d.Color[1]
+		}
+		ok = true
+	}
+	return
+}
+
 func (o LoggerLevel) Int() int {
-	if i, ok := levelIntegers[o]; ok {
-		return i
+	levelMutex.RLock()
+	defer levelMutex.RUnlock()
+
+	if d, ok := levelIntegers[o]; ok {
+		return d.Int
 	}
 	return 0
 }
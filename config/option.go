@@ -23,8 +23,119 @@ func ServiceName(s string) Option    { return func(c *configuration) { c.Service
 func ServicePort(p int) Option       { return func(c *configuration) { c.ServicePort = p } }
 func ServiceVersion(s string) Option { return func(c *configuration) { c.ServiceVersion = s } }
 
+func LoggerAsyncEnabled(b bool) Option { return func(c *configuration) { c.LoggerAsync.Enabled = b } }
+
+func LoggerAsyncCapacity(n int) Option {
+	return func(c *configuration) { c.LoggerAsync.Capacity = n; c.LoggerAsync.initDefaults() }
+}
+
+func LoggerAsyncDropPolicy(p LoggerAsyncPolicy) Option {
+	return func(c *configuration) { c.LoggerAsync.Policy = p; c.LoggerAsync.initDefaults() }
+}
+
+func ElasticsearchAddresses(s ...string) Option {
+	return func(c *configuration) { c.ElasticsearchLogger.Addresses = s }
+}
+
+func ElasticsearchIndex(s string) Option {
+	return func(c *configuration) { c.ElasticsearchLogger.Index = s }
+}
+
+func FileLoggerCompress(b bool) Option { return func(c *configuration) { c.FileLogger.Compress = b } }
+func FileLoggerMaxAge(n int) Option    { return func(c *configuration) { c.FileLogger.MaxAge = n } }
+func FileLoggerMaxSize(n int) Option   { return func(c *configuration) { c.FileLogger.MaxSize = n } }
+func FileLoggerPath(s string) Option   { return func(c *configuration) { c.FileLogger.Path = s } }
+
+func FileLoggerFormat(f LoggerFormat) Option {
+	return func(c *configuration) { c.FileLogger.Format = f; c.FileLogger.initDefaults() }
+}
+
+func FileLoggerMaxBackups(n int) Option {
+	return func(c *configuration) { c.FileLogger.MaxBackups = n }
+}
+
+func FileLoggerRotateTime(t RotateTime) Option {
+	return func(c *configuration) { c.FileLogger.RotateTime = t }
+}
+
+func FileLoggerSplitLevels(levels ...LoggerLevel) Option {
+	return func(c *configuration) { c.FileLogger.SplitLevels = levels; c.FileLogger.initDefaults() }
+}
+
+func FluentdAddress(network, address string) Option {
+	return func(c *configuration) { c.FluentdLogger.Network = network; c.FluentdLogger.Address = address }
+}
+
+func GelfAddress(network, address string) Option {
+	return func(c *configuration) { c.GelfLogger.Network = network; c.GelfLogger.Address = address }
+}
+
+func GelfCompression(s string) Option {
+	return func(c *configuration) { c.GelfLogger.Compression = s; c.GelfLogger.initDefaults() }
+}
+
+func JournaldSocket(s string) Option { return func(c *configuration) { c.JournaldLogger.Socket = s } }
+
+func KafkaBrokers(s ...string) Option { return func(c *configuration) { c.KafkaLogger.Brokers = s } }
+func KafkaTopic(s string) Option      { return func(c *configuration) { c.KafkaLogger.Topic = s } }
+
+func KafkaCompression(s string) Option {
+	return func(c *configuration) { c.KafkaLogger.Compression = s; c.KafkaLogger.initDefaults(c) }
+}
+
+func LokiLabels(s ...string) Option { return func(c *configuration) { c.LokiLogger.Labels = s } }
+func LokiUrl(s string) Option       { return func(c *configuration) { c.LokiLogger.Url = s } }
+
+func OtlpEndpoint(s string) Option { return func(c *configuration) { c.OtlpLogger.Endpoint = s } }
+
+func OtlpEncodingType(e OtlpEncoding) Option {
+	return func(c *configuration) { c.OtlpLogger.Encoding = e; c.OtlpLogger.initDefaults() }
+}
+
+func SyslogAddress(network, address string) Option {
+	return func(c *configuration) { c.SyslogLogger.Network = network; c.SyslogLogger.Address = address }
+}
+
+func SyslogMessageFormat(f SyslogFormat) Option {
+	return func(c *configuration) { c.SyslogLogger.Format = f; c.SyslogLogger.initDefaults() }
+}
+
+func TermLoggerAttributes(s ...string) Option {
+	return func(c *configuration) { c.TermLogger.Attributes = s }
+}
+
+func TermLoggerFormat(f LoggerFormat) Option {
+	return func(c *configuration) { c.TermLogger.Format = f; c.TermLogger.initDefaults() }
+}
+
+func OpenTracingFormat(formats ...PropagationFormat) Option {
+	return func(c *configuration) { c.OpenTracingFormat = formats }
+}
+
+func BaggageLogFields(s ...string) Option {
+	return func(c *configuration) { c.Baggage.LogFields = s }
+}
+
+func BaggageSpanAttributes(s ...string) Option {
+	return func(c *configuration) { c.Baggage.SpanAttributes = s }
+}
+
+// SamplingRules
+// replace sampling rules and default ratio.
+func SamplingRules(defaultRatio float64, rules ...SamplingRule) Option {
+	return func(c *configuration) {
+		c.setSampling(&samplingConfiguration{DefaultRatio: &defaultRatio, Rules: rules})
+	}
+}
+
+func LoggerCaller(b bool) Option { return func(c *configuration) { c.LoggerCaller = b } }
+
 func TracerTopic(s string) Option { return func(c *configuration) { c.TracerTopic = s } }
 
 func JaegerEndpoint(s string) Option { return func(c *configuration) { c.JaegerTrace.Endpoint = s } }
 func JaegerPassword(s string) Option { return func(c *configuration) { c.JaegerTrace.Password = s } }
 func JaegerUsername(s string) Option { return func(c *configuration) { c.JaegerTrace.Username = s } }
+
+func JaegerSamplingEndpoint(s string) Option {
+	return func(c *configuration) { c.JaegerTrace.SamplingEndpoint = s }
+}
@@ -16,11 +16,80 @@
 package config
 
 type (
+	// PropagationFormat
+	// header format of trace context across services.
+	PropagationFormat string
+
+	// SamplingRule
+	// sampling of traces matched, all specified conditions must be
+	// matched. Patterns support * wildcard.
+	SamplingRule struct {
+		// Name
+		// pattern of trace name.
+		Name string `yaml:"name"`
+
+		// Method
+		// pattern of http request method, case-insensitive.
+		Method string `yaml:"method"`
+
+		// Path
+		// pattern of http request path, query string excluded.
+		Path string `yaml:"path"`
+
+		// Ratio
+		// ratio of matched traces sampled, used if rate-limit is
+		// not specified. Zero discards all matched traces.
+		Ratio float64 `yaml:"ratio"`
+
+		// RateLimit
+		// maximum matched traces sampled per second.
+		RateLimit float64 `yaml:"rate-limit"`
+	}
+
 	// TracerName
 	// name of trace exporter.
 	TracerName string
 )
 
+const (
+	// PropagationB3
+	// B3 multiple headers, names configured by open-tracing-trace-id,
+	// open-tracing-span-id and open-tracing-sample.
+	PropagationB3 PropagationFormat = "b3"
+
+	// PropagationBaggage
+	// W3C baggage header.
+	PropagationBaggage PropagationFormat = "baggage"
+
+	// PropagationB3Single
+	// B3 single header, such as sent by envoy.
+	PropagationB3Single PropagationFormat = "b3-single"
+
+	// PropagationJaeger
+	// jaeger uber-trace-id header.
+	PropagationJaeger PropagationFormat = "jaeger"
+
+	// PropagationJaegerBaggage
+	// jaeger uberctx-{key} headers of baggage.
+	PropagationJaegerBaggage PropagationFormat = "jaeger-baggage"
+
+	// PropagationW3c
+	// W3C trace context, traceparent and tracestate headers.
+	PropagationW3c PropagationFormat = "w3c"
+)
+
+const (
+	DefaultBaggageMaxBytes   = 8192
+	DefaultBaggageMaxMembers = 180
+	DefaultSamplingRatio     = 1.0
+)
+
+const (
+	DefaultJaegerSamplingEndpoint = "http://localhost:5778/sampling"
+	DefaultJaegerSamplingRefresh  = 60000
+	DefaultJaegerSamplingTimeout  = 5000
+)
+
 const (
 	TracerJaeger TracerName = "jaeger"
 	TracerTerm   TracerName = "term"
@@ -26,7 +26,8 @@ func init() {
 
 	log.Provider.SetLoggerExporter(logger_term.New())
 	log.Provider.SetTracerExporter(tracer_term.New())
-	// log.Provider.SetTracerExporter(tracer_jaeger.New())
+	// log.Provider.AddTracerExporter(tracer_jaeger.New())
+	// log.Provider.AddLoggerExporter(logger_file.New(), tracer.WithMinLevel(config.Warn))
 
 	ctx := context.Background()
 	_ = log.Provider.Start(ctx)
@@ -0,0 +1,292 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+// Package batch
+// buffer logs and send them in batches, used by elasticsearch,
+// fluentd, kafka, loki and otlp logger.
+package batch
+
+import (
+	"context"
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultMaxBuffer
+	// maximum count of buffered logs, logs discarded by drop policy
+	// if sender can not keep up.
+	DefaultMaxBuffer = 10000
+)
+
+type (
+	// Batcher
+	// buffer logs, buffered logs sent when batch is full, batch
+	// timeout elapsed or batcher stopped.
+	Batcher interface {
+		// Dropped
+		// returns count of logs discarded as buffer is full.
+		Dropped() uint64
+
+		// Flush
+		// send buffered logs in batches, the last error returned.
+		Flush() error
+
+		// Push
+		// log into buffer, buffer sent immediately if batcher is
+		// not started.
+		Push(log *tracer.Log) error
+
+		// Start
+		// send buffered logs in coroutine until ctx done, logs
+		// buffered before stopped are sent before return.
+		Start(ctx context.Context) error
+
+		Stopped() bool
+	}
+
+	// Option
+	// custom batcher options.
+	Option func(o *batcher)
+
+	// Sender
+	// send a batch of logs, called in sequence.
+	Sender func(logs []*tracer.Log) error
+
+	batcher struct {
+		maxBuffer int
+		name      string
+		policy    config.LoggerAsyncPolicy
+		sender    Sender
+		size      int
+
+		timeout time.Duration
+
+		// dropped, reported
+		// count of discarded logs and count reported to stderr.
+		dropped, reported uint64
+
+		// bufferMutex
+		// protect buffer and stopped, logs pushed after stopped are
+		// sent by pusher.
+		bufferMutex sync.Mutex
+		buffer      []*tracer.Log
+		stopped     bool
+
+		flushMutex sync.Mutex
+		notify     chan bool
+	}
+)
+
+// New
+// returns a batcher send at most size logs in a batch, errors and
+// discarded logs of coroutine reported to stderr with name.
+func New(name string, size int, timeout time.Duration, sender Sender, opts ...Option) Batcher {
+	if size <= 0 {
+		size = 1
+	}
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+
+	o := &batcher{
+		maxBuffer: DefaultMaxBuffer,
+		name:      name,
+		notify:    make(chan bool, 1),
+		policy:    config.AsyncDropOldest,
+		sender:    sender,
+		size:      size,
+		stopped:   true,
+		timeout:   timeout,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.maxBuffer < size {
+		o.maxBuffer = size
+	}
+	return o
+}
+
+// WithMaxBuffer
+// use specified maximum count of buffered logs, at least batch size.
+// Ignored if n is not positive.
+func WithMaxBuffer(n int) Option {
+	return func(o *batcher) {
+		if n > 0 {
+			o.maxBuffer = n
+		}
+	}
+}
+
+// WithPolicy
+// use specified policy if buffer is full, accepts drop-newest,
+// drop-oldest and keep-error. Block is not supported as pusher would
+// wait for remote server, drop-oldest used.
+func WithPolicy(policy config.LoggerAsyncPolicy) Option {
+	return func(o *batcher) {
+		switch policy {
+		case config.AsyncDropNewest, config.AsyncDropOldest, config.AsyncKeepError:
+			o.policy = policy
+		}
+	}
+}
+
+func (o *batcher) Dropped() uint64 { return atomic.LoadUint64(&o.dropped) }
+
+func (o *batcher) Flush() (err error) {
+	o.flushMutex.Lock()
+	defer o.flushMutex.Unlock()
+
+	o.bufferMutex.Lock()
+	list := o.buffer
+	o.buffer = nil
+	o.bufferMutex.Unlock()
+
+	for len(list) > 0 {
+		n := o.size
+		if n > len(list) {
+			n = len(list)
+		}
+		if se := o.sender(list[:n]); se != nil {
+			err = se
+		}
+		list = list[n:]
+	}
+	return
+}
+
+func (o *batcher) Push(log *tracer.Log) error {
+	o.bufferMutex.Lock()
+	o.append(log)
+	n, stopped := len(o.buffer), o.stopped
+	o.bufferMutex.Unlock()
+
+	if stopped {
+		return o.Flush()
+	}
+
+	// Notify
+	// coroutine if batch is full.
+	if n >= o.size {
+		select {
+		case o.notify <- true:
+		default:
+		}
+	}
+	return nil
+}
+
+func (o *batcher) Start(ctx context.Context) error {
+	o.bufferMutex.Lock()
+	o.stopped = false
+	o.bufferMutex.Unlock()
+
+	ticker := time.NewTicker(o.timeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			o.report(o.Flush())
+			o.reportDropped()
+		case <-o.notify:
+			o.report(o.Flush())
+			o.reportDropped()
+		case <-ctx.Done():
+			// Stop
+			// before the final flush, logs pushed later are
+			// sent by pusher.
+			o.bufferMutex.Lock()
+			o.stopped = true
+			o.bufferMutex.Unlock()
+			return o.Flush()
+		}
+	}
+}
+
+func (o *batcher) Stopped() bool {
+	o.bufferMutex.Lock()
+	defer o.bufferMutex.Unlock()
+	return o.stopped
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Batcher: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// append
+// log into buffer, caller must hold buffer lock. A log is discarded
+// by policy if buffer is full.
+func (o *batcher) append(log *tracer.Log) {
+	if len(o.buffer) < o.maxBuffer {
+		o.buffer = append(o.buffer, log)
+		return
+	}
+
+	atomic.AddUint64(&o.dropped, 1)
+
+	switch o.policy {
+	case config.AsyncDropNewest:
+		return
+
+	case config.AsyncKeepError:
+		if !severe(log) {
+			return
+		}
+
+		// Evict
+		// the oldest log lower than ERROR.
+		for i, x := range o.buffer {
+			if !severe(x) {
+				copy(o.buffer[i:], o.buffer[i+1:])
+				o.buffer[len(o.buffer)-1] = log
+				return
+			}
+		}
+	}
+
+	// Oldest
+	// discarded.
+	copy(o.buffer, o.buffer[1:])
+	o.buffer[len(o.buffer)-1] = log
+}
+
+func (o *batcher) report(err error) {
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: %v\n", o.name, err)
+	}
+}
+
+// reportDropped
+// report count of logs discarded since last report.
+func (o *batcher) reportDropped() {
+	if n := atomic.LoadUint64(&o.dropped); n > o.reported {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: %d logs dropped as buffer is full\n", o.name, n-o.reported)
+		o.reported = n
+	}
+}
+
+// severe
+// return true if level of log is ERROR or more severe.
+func severe(log *tracer.Log) bool {
+	i := log.Level.Int()
+	return i > config.Off.Int() && i <= config.Error.Int()
+}
@@ -0,0 +1,137 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package batch
+
+import (
+	"context"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testSender struct {
+	sync.Mutex
+	batches [][]*tracer.Log
+}
+
+func (o *testSender) count() (batches, logs int) {
+	o.Lock()
+	defer o.Unlock()
+	for _, b := range o.batches {
+		logs += len(b)
+	}
+	return len(o.batches), logs
+}
+
+func (o *testSender) send(logs []*tracer.Log) error {
+	o.Lock()
+	o.batches = append(o.batches, logs)
+	o.Unlock()
+	return nil
+}
+
+func TestBatcher_Push(t *testing.T) {
+	s := &testSender{}
+	b := New("test", 10, time.Hour, s.send)
+
+	// Not started
+	// sent immediately.
+	_ = b.Push(tracer.NewLog(tracer.LogInternal, config.Info))
+	if batches, logs := s.count(); !b.Stopped() || batches != 1 || logs != 1 {
+		t.Fatalf("expect log sent immediately, got %d batches %d logs", batches, logs)
+	}
+}
+
+func TestBatcher_Start(t *testing.T) {
+	s := &testSender{}
+	b := New("test", 2, time.Hour, s.send)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- b.Start(ctx) }()
+
+	for deadline := time.Now().Add(time.Second); b.Stopped(); {
+		if time.Now().After(deadline) {
+			t.Fatalf("batcher not started")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Full batch
+	// sent by coroutine.
+	for i := 0; i < 2; i++ {
+		_ = b.Push(tracer.NewLog(tracer.LogInternal, config.Info))
+	}
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		if batches, _ := s.count(); batches == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expect full batch sent")
+		}
+	}
+
+	// Stop
+	// buffered logs sent, logs pushed later sent by pusher.
+	_ = b.Push(tracer.NewLog(tracer.LogInternal, config.Info))
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("stop error: %v", err)
+	}
+	_ = b.Push(tracer.NewLog(tracer.LogInternal, config.Info))
+
+	if batches, logs := s.count(); !b.Stopped() || batches != 3 || logs != 4 {
+		t.Errorf("expect 3 batches of 4 logs, got %d batches %d logs", batches, logs)
+	}
+}
+
+func TestBatcher_MaxBuffer(t *testing.T) {
+	for _, c := range []struct {
+		policy config.LoggerAsyncPolicy
+		levels []config.LoggerLevel
+		texts  []string
+	}{
+		{config.AsyncDropNewest, []config.LoggerLevel{config.Info, config.Info, config.Info, config.Info}, []string{"0", "1", "2"}},
+		{config.AsyncDropOldest, []config.LoggerLevel{config.Info, config.Info, config.Info, config.Info}, []string{"1", "2", "3"}},
+		{config.AsyncKeepError, []config.LoggerLevel{config.Error, config.Info, config.Info, config.Info, config.Fatal}, []string{"0", "2", "4"}},
+	} {
+		// Started
+		// batcher buffer logs without sending.
+		b := New("test", 2, time.Hour, (&testSender{}).send, WithMaxBuffer(3), WithPolicy(c.policy)).(*batcher)
+		b.stopped = false
+
+		for i, level := range c.levels {
+			x := tracer.NewLog(tracer.LogInternal, level)
+			x.Text = strconv.Itoa(i)
+			_ = b.Push(x)
+		}
+
+		texts := make([]string, 0, len(b.buffer))
+		for _, x := range b.buffer {
+			texts = append(texts, x.Text)
+		}
+		if strings.Join(texts, ",") != strings.Join(c.texts, ",") {
+			t.Errorf("policy %s: expect %v, got %v", c.policy, c.texts, texts)
+		}
+		if n := b.Dropped(); n != uint64(len(c.levels)-len(c.texts)) {
+			t.Errorf("policy %s: expect %d dropped, got %d", c.policy, len(c.levels)-len(c.texts), n)
+		}
+	}
+}
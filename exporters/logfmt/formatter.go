@@ -0,0 +1,170 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+// Package logfmt
+// generate log as logfmt line, used by term and file logger.
+//
+//	ts=2023-02-24T15:04:05.123+08:00 level=info msg="user login" trace_id=... user=1
+package logfmt
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/fuyibing/log/tracer"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type (
+	Formatter interface {
+		Format(log *tracer.Log) string
+	}
+
+	formatter struct {
+		attributes []string
+	}
+)
+
+// New
+// returns a logfmt formatter, provider attributes of specified keys
+// appended in order.
+func New(attributes ...string) Formatter {
+	return (&formatter{attributes: attributes}).init()
+}
+
+// Format
+// generate log as logfmt line with deterministic key order: ts, level,
+// msg, trace_id, span_id, caller, fields sorted by key, then provider
+// attributes.
+func (o *formatter) Format(log *tracer.Log) string {
+	buf := &strings.Builder{}
+
+	o.write(buf, "ts", log.Time.Format(time.RFC3339Nano))
+	o.write(buf, "level", strings.ToLower(log.Level.String()))
+	o.write(buf, "msg", log.Text)
+
+	if !log.TraceId.IsZero() {
+		o.write(buf, "trace_id", log.TraceId.String())
+		o.write(buf, "span_id", log.SpanId.String())
+	}
+	if log.Caller != "" {
+		o.write(buf, "caller", log.Caller)
+	}
+
+	// Fields
+	// sorted by key.
+	if len(log.Fields) > 0 {
+		keys := make([]string, 0, len(log.Fields))
+		for k := range log.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			o.write(buf, k, log.Fields[k])
+		}
+	}
+
+	// Provider attributes
+	// of log in specified order.
+	for _, k := range o.attributes {
+		if v, ok := log.Attr[k]; ok {
+			o.write(buf, k, v)
+		}
+	}
+
+	return buf.String()
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Formatter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *formatter) init() *formatter {
+	return o
+}
+
+func (o *formatter) write(buf *strings.Builder, key string, value interface{}) {
+	if buf.Len() > 0 {
+		buf.WriteByte(' ')
+	}
+	buf.WriteString(Key(key))
+	buf.WriteByte('=')
+	buf.WriteString(Value(value))
+}
+
+// Key
+// returns a valid logfmt key, space, equal sign, quote and control
+// characters replaced with underscore.
+func Key(key string) string {
+	if key == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == unicode.ReplacementChar || !unicode.IsPrint(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+// Value
+// returns a logfmt value, quoted if it contains space, equal sign,
+// quote or control characters.
+func Value(value interface{}) string {
+	var s string
+
+	switch v := value.(type) {
+	case nil:
+		s = "null"
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case error:
+		s = v.Error()
+	case time.Time:
+		s = v.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		s = v.String()
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		s = fmt.Sprintf("%v", v)
+	default:
+		if buf, err := json.Marshal(v); err == nil {
+			s = string(buf)
+		} else {
+			s = fmt.Sprintf("%v", v)
+		}
+	}
+
+	if needQuote(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func needQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == unicode.ReplacementChar || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logfmt
+
+import (
+	"errors"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"github.com/fuyibing/log/tracetest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValue(t *testing.T) {
+	for value, expect := range map[interface{}]string{
+		"plain":           "plain",
+		"with space":      `"with space"`,
+		`say "hi"`:        `"say \"hi\""`,
+		"line1\nline2":    `"line1\nline2"`,
+		"a=b":             `"a=b"`,
+		"":                `""`,
+		"中文":              "中文",
+		3:                 "3",
+		true:              "true",
+		errors.New("x y"): `"x y"`,
+	} {
+		if got := Value(value); got != expect {
+			t.Errorf("value %q: expect %s, got %s", value, expect, got)
+		}
+	}
+
+	if got := Key("user name=\"x\""); got != "user_name__x_" {
+		t.Errorf("unexpected key: %s", got)
+	}
+}
+
+func TestFormatter_Format(t *testing.T) {
+	var (
+		e = tracetest.NewLoggerExporter()
+		p = tracer.NewProvider()
+	)
+
+	// Attributes
+	// of provider which log sent by.
+	p.SetAttr("service.name", "logfmt test")
+	p.AddLoggerExporter(e)
+	tracer.Provider.SetAttr("service.name", "global")
+
+	x := tracer.NewLog(tracer.LogInternal, config.Info)
+	x.Time = time.Date(2023, 2, 24, 15, 4, 5, 0, time.UTC)
+	x.Text = "user login"
+	x.Fields = tracer.Attr{"user": 1, "agent": "curl/7", "roles": []string{"a", "b"}}
+	p.PushLog(x)
+	x = e.Logs()[0]
+
+	expect := `ts=2023-02-24T15:04:05Z level=info msg="user login" agent=curl/7 roles="[\"a\",\"b\"]" user=1 service.name="logfmt test"`
+	for i := 0; i < 3; i++ {
+		if got := New("service.name").Format(x); got != expect {
+			t.Fatalf("expect %s, got %s", expect, got)
+		}
+	}
+
+	if strings.Contains(New().Format(x), "service.name") {
+		t.Errorf("attributes should not be included")
+	}
+}
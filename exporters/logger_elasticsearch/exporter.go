@@ -0,0 +1,326 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/exporters/batch"
+	"github.com/fuyibing/log/tracer"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type (
+	Exporter interface {
+		Push(log *tracer.Log) error
+		Start(ctx context.Context) error
+		Stopped() bool
+	}
+
+	// Option
+	// custom exporter options.
+	Option func(o *exporter)
+
+	exporter struct {
+		addresses    []string
+		apiKey       string
+		batcher      batch.Batcher
+		client       *http.Client
+		formatter    Formatter
+		index        string
+		password     string
+		retries      int
+		retryBackoff time.Duration
+		username     string
+
+		// next
+		// index of address to send, used by sender in sequence.
+		next int
+	}
+
+	// document
+	// an encoded log and name of index.
+	document struct {
+		index string
+		body  []byte
+	}
+
+	// bulkResponse
+	// result of bulk api, items in order of request.
+	bulkResponse struct {
+		Errors bool                          `json:"errors"`
+		Items  []map[string]bulkResponseItem `json:"items"`
+	}
+
+	bulkResponseItem struct {
+		Status int `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	}
+
+	// statusError
+	// returned if bulk request responds with non 2xx status.
+	statusError struct {
+		code int
+		body string
+	}
+)
+
+// New
+// returns an elasticsearch logger exporter, configured by
+// elasticsearch-logger section of log.yaml. Opensearch is compatible.
+func New(opts ...Option) Exporter {
+	o := (&exporter{}).init()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithClient
+// use specified http client.
+func WithClient(c *http.Client) Option {
+	return func(o *exporter) {
+		if c != nil {
+			o.client = c
+		}
+	}
+}
+
+// WithFormatter
+// use specified formatter.
+func WithFormatter(f Formatter) Option {
+	return func(o *exporter) {
+		if f != nil {
+			o.formatter = f
+		}
+	}
+}
+
+// Push
+// log into batch buffer, buffer sent immediately if exporter is not
+// started.
+func (o *exporter) Push(log *tracer.Log) error { return o.batcher.Push(log) }
+
+func (o *exporter) Start(ctx context.Context) error { return o.batcher.Start(ctx) }
+
+func (o *exporter) Stopped() bool { return o.batcher.Stopped() }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Exporter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// bulk
+// send documents, returns documents failed with retriable status.
+// Documents failed with other status are dropped and reported in
+// dropped error.
+func (o *exporter) bulk(docs []*document) (retry []*document, dropped, err error) {
+	var (
+		body = &bytes.Buffer{}
+		res  *bulkResponse
+	)
+
+	for _, doc := range docs {
+		body.WriteString(`{"create":{"_index":`)
+		buf, _ := json.Marshal(doc.index)
+		body.Write(buf)
+		body.WriteString("}}\n")
+		body.Write(doc.body)
+		body.WriteByte('\n')
+	}
+
+	if res, err = o.post(body.Bytes()); err != nil {
+		if se, ok := err.(*statusError); ok && !se.Retriable() {
+			return nil, err, nil
+		}
+		return docs, nil, err
+	}
+	if !res.Errors {
+		return
+	}
+
+	var reasons []string
+	for i, item := range res.Items {
+		if i >= len(docs) {
+			break
+		}
+		for _, r := range item {
+			if r.Status < 300 {
+				continue
+			}
+			if r.Status == http.StatusTooManyRequests || r.Status >= 500 {
+				retry = append(retry, docs[i])
+				continue
+			}
+			if r.Error != nil {
+				reasons = append(reasons, fmt.Sprintf("%s: %s", r.Error.Type, r.Error.Reason))
+			} else {
+				reasons = append(reasons, fmt.Sprintf("status %d", r.Status))
+			}
+		}
+	}
+
+	if len(reasons) > 0 {
+		dropped = fmt.Errorf("%d documents dropped: %s", len(reasons), reasons[0])
+	}
+	if len(retry) > 0 {
+		err = fmt.Errorf("%d documents failed", len(retry))
+	}
+	return
+}
+
+func (o *exporter) init() *exporter {
+	c := config.Config.GetElasticsearchLogger()
+
+	o.addresses = c.GetAddresses()
+	o.apiKey = c.GetApiKey()
+	o.batcher = batch.New("elasticsearch logger", c.GetBatchSize(), time.Duration(c.GetBatchTimeout())*time.Millisecond, o.send)
+	o.client = &http.Client{Timeout: time.Duration(c.GetTimeout()) * time.Millisecond}
+	o.formatter = (&formatter{}).init()
+	o.index = c.GetIndex()
+	o.password = c.GetPassword()
+	o.retries = c.GetRetries()
+	o.retryBackoff = time.Duration(c.GetRetryBackoff()) * time.Millisecond
+	o.username = c.GetUsername()
+	return o
+}
+
+// post
+// send bulk request body to current node, next node used if request
+// failed.
+func (o *exporter) post(body []byte) (res *bulkResponse, err error) {
+	var (
+		req  *http.Request
+		resp *http.Response
+		addr = strings.TrimRight(o.addresses[o.next%len(o.addresses)], "/")
+	)
+
+	defer func() {
+		if err != nil {
+			o.next++
+		}
+	}()
+
+	if req, err = http.NewRequest(http.MethodPost, addr+"/_bulk", bytes.NewReader(body)); err != nil {
+		return
+	}
+
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if o.apiKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+o.apiKey)
+	} else if o.username != "" {
+		req.SetBasicAuth(o.username, o.password)
+	}
+
+	if resp, err = o.client.Do(req); err != nil {
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode/100 != 2 {
+		buf, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err = &statusError{code: resp.StatusCode, body: strings.TrimSpace(string(buf))}
+		return
+	}
+
+	res = &bulkResponse{}
+	err = json.NewDecoder(resp.Body).Decode(res)
+	return
+}
+
+// send
+// encode logs and send them in a bulk request, retry failed documents
+// with exponential backoff.
+func (o *exporter) send(logs []*tracer.Log) (err error) {
+	docs := make([]*document, 0, len(logs))
+	for _, log := range logs {
+		doc := &document{index: indexName(o.index, log.Time)}
+		if doc.body, err = o.formatter.Format(log); err != nil {
+			return
+		}
+		docs = append(docs, doc)
+	}
+
+	var (
+		backoff = o.retryBackoff
+		dropped error
+	)
+
+	for attempt := 0; attempt <= o.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		var de error
+		if docs, de, err = o.bulk(docs); de != nil {
+			dropped = de
+		}
+		if len(docs) == 0 {
+			return dropped
+		}
+	}
+	return
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Status error
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *statusError) Error() string {
+	return fmt.Sprintf("status %d: %s", o.code, o.body)
+}
+
+// Retriable
+// return true if status is 429 or 5xx.
+func (o *statusError) Retriable() bool {
+	return o.code == http.StatusTooManyRequests || o.code >= 500
+}
+
+// indexName
+// returns index name of time in UTC, placeholders such as {2006.01.02}
+// replaced with time formatted as go layout.
+func indexName(pattern string, t time.Time) string {
+	var (
+		buf = &strings.Builder{}
+		utc = t.UTC()
+	)
+
+	for {
+		i := strings.IndexByte(pattern, '{')
+		if i < 0 {
+			break
+		}
+		j := strings.IndexByte(pattern[i:], '}')
+		if j < 0 {
+			break
+		}
+		buf.WriteString(pattern[:i])
+		buf.WriteString(utc.Format(pattern[i+1 : i+j]))
+		pattern = pattern[i+j+1:]
+	}
+
+	buf.WriteString(pattern)
+	return buf.String()
+}
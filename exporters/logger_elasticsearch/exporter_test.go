@@ -0,0 +1,186 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_elasticsearch
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExporter_Send(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests [][]string
+	)
+
+	// Server
+	// first request: item 0 created, item 1 rejected by 429, item 2
+	// rejected by mapping error. Second request: all created.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_bulk" || r.Header.Get("Content-Type") != "application/x-ndjson" {
+			t.Errorf("unexpected request: %s %s", r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		if u, p, ok := r.BasicAuth(); !ok || u != "elastic" || p != "secret" {
+			t.Errorf("unexpected basic auth: %s %s", u, p)
+		}
+
+		var lines []string
+		scanner := bufio.NewScanner(r.Body)
+		scanner.Buffer(make([]byte, 1<<20), 1<<20)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+
+		mu.Lock()
+		requests = append(requests, lines)
+		n := len(requests)
+		mu.Unlock()
+
+		if n == 1 {
+			_, _ = fmt.Fprint(w, `{"errors":true,"items":[`+
+				`{"create":{"status":201}},`+
+				`{"create":{"status":429,"error":{"type":"es_rejected_execution_exception","reason":"queue full"}}},`+
+				`{"create":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}]}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"errors":false,"items":[{"create":{"status":201}}]}`)
+	}))
+	defer server.Close()
+
+	config.Config.With(config.ElasticsearchAddresses(server.URL), config.ElasticsearchIndex("app-v2-{2006.01.02}"))
+
+	o := New().(*exporter)
+	o.password = "secret"
+	o.retries = 3
+	o.retryBackoff = time.Millisecond
+	o.username = "elastic"
+
+	attr := tracer.Attr{"runtime.host": "es-host", "runtime.version": runtime.Version()}
+	logs := make([]*tracer.Log, 3)
+	for i := range logs {
+		logs[i] = tracer.NewLog(tracer.LogSpan, config.Info)
+		logs[i].Attr = attr
+		logs[i].Text = fmt.Sprintf("message %d", i)
+		logs[i].Time = time.Date(2023, 2, 24, 23, 0, 0, 0, time.FixedZone("CST", -3600))
+	}
+	logs[0].TraceId = tracer.Identify.NewTraceId()
+	logs[0].SpanId = tracer.Identify.NewSpanId()
+	logs[0].Caller = "dir/main.go:12"
+	logs[0].Fields = tracer.Attr{"user": 1}
+
+	err := o.send(logs)
+	if err == nil || !strings.Contains(err.Error(), "mapper_parsing_exception") {
+		t.Errorf("expect dropped error, got %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("expect 2 requests, got %d", len(requests))
+	}
+	if len(requests[0]) != 6 || len(requests[1]) != 2 {
+		t.Fatalf("unexpected lines: %d, %d", len(requests[0]), len(requests[1]))
+	}
+	if requests[0][0] != `{"create":{"_index":"app-v2-2023.02.25"}}` {
+		t.Errorf("unexpected action: %s", requests[0][0])
+	}
+	if !strings.Contains(requests[1][1], "message 1") {
+		t.Errorf("expect retried message 1, got %s", requests[1][1])
+	}
+
+	var doc map[string]interface{}
+	if err = json.Unmarshal([]byte(requests[0][1]), &doc); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	for path, expect := range map[string]interface{}{
+		"@timestamp":             "2023-02-25T00:00:00Z",
+		"message":                "message 0",
+		"log.level":              "info",
+		"log.origin.file.name":   "dir/main.go",
+		"log.origin.file.line":   float64(12),
+		"trace.id":               logs[0].TraceId.String(),
+		"span.id":                logs[0].SpanId.String(),
+		"labels.user":            float64(1),
+		"labels.runtime_version": runtime.Version(),
+		"host.name":              "es-host",
+		"runtime":                nil,
+	} {
+		if v := lookup(doc, path); v != expect {
+			t.Errorf("field %s: expect %v, got %v", path, expect, v)
+		}
+	}
+}
+
+func TestIndexName(t *testing.T) {
+	tm := time.Date(2026, 10, 18, 1, 0, 0, 0, time.UTC)
+	for pattern, name := range map[string]string{
+		"app-v2-{2006.01.02}":  "app-v2-2026.10.18",
+		"Mon-Jan-13-{2006.01}": "Mon-Jan-13-2026.10",
+		"logs-{2006}.{01}":     "logs-2026.10",
+		"static-index":         "static-index",
+		"broken-{2006":         "broken-{2006",
+	} {
+		if v := indexName(pattern, tm); v != name {
+			t.Errorf("index name of %q: expect %q, got %q", pattern, name, v)
+		}
+	}
+}
+
+func TestExporter_Failover(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "ApiKey key" {
+			t.Errorf("unexpected authorization: %s", r.Header.Get("Authorization"))
+		}
+		_, _ = fmt.Fprint(w, `{"errors":false,"items":[{"create":{"status":201}}]}`)
+	}))
+	defer up.Close()
+
+	config.Config.With(config.ElasticsearchAddresses(down.URL, up.URL))
+
+	o := New().(*exporter)
+	o.apiKey = "key"
+	o.retries = 1
+	o.retryBackoff = time.Millisecond
+
+	if err := o.send([]*tracer.Log{tracer.NewLog(tracer.LogSpan, config.Info)}); err != nil {
+		t.Errorf("send error: %v", err)
+	}
+}
+
+func lookup(doc map[string]interface{}, path string) interface{} {
+	var v interface{} = doc
+	for _, k := range strings.Split(path, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[k]
+	}
+	return v
+}
@@ -0,0 +1,131 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_elasticsearch
+
+import (
+	"encoding/json"
+	"github.com/fuyibing/log/tracer"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EcsVersion
+// version of elastic common schema.
+const EcsVersion = "8.11.0"
+
+var (
+	// ecsAttributes
+	// provider attributes mapped to ecs fields, others stored in
+	// labels.
+	ecsAttributes = map[string]string{
+		"runtime.host":    "host.name",
+		"runtime.pid":     "process.pid",
+		"service.name":    "service.name",
+		"service.version": "service.version",
+	}
+)
+
+type (
+	Formatter interface {
+		Format(log *tracer.Log) ([]byte, error)
+	}
+
+	formatter struct {
+	}
+)
+
+// Format
+// generate log as elastic common schema document. Provider attributes
+// mapped to ecs fields such as service.name expanded into objects,
+// other attributes and log fields stored in labels, dots in names of
+// attributes replaced with underscore.
+//
+//	{
+//	  "@timestamp": "2023-02-24T07:04:05.123Z",
+//	  "message": "user login",
+//	  "ecs": {"version": "8.11.0"},
+//	  "log": {"level": "info", "origin": {"file": {"name": "main.go", "line": 12}}},
+//	  "trace": {"id": "..."},
+//	  "span": {"id": "..."},
+//	  "service": {"name": "app"},
+//	  "labels": {"service_port": 8080, "user": 1}
+//	}
+func (o *formatter) Format(log *tracer.Log) ([]byte, error) {
+	var (
+		doc    = map[string]interface{}{}
+		labels = map[string]interface{}{}
+	)
+
+	for k, v := range log.Attr {
+		if field, ok := ecsAttributes[k]; ok {
+			o.set(doc, field, v)
+		} else {
+			labels[strings.ReplaceAll(k, ".", "_")] = v
+		}
+	}
+
+	o.set(doc, "@timestamp", log.Time.UTC().Format(time.RFC3339Nano))
+	o.set(doc, "message", log.Text)
+	o.set(doc, "ecs.version", EcsVersion)
+	o.set(doc, "log.level", strings.ToLower(log.Level.String()))
+
+	if !log.TraceId.IsZero() {
+		o.set(doc, "trace.id", log.TraceId.String())
+		o.set(doc, "span.id", log.SpanId.String())
+	}
+
+	// Caller
+	// such as dir/file.go:12.
+	if i := strings.LastIndex(log.Caller, ":"); i > 0 {
+		o.set(doc, "log.origin.file.name", log.Caller[:i])
+		if n, err := strconv.Atoi(log.Caller[i+1:]); err == nil {
+			o.set(doc, "log.origin.file.line", n)
+		}
+	}
+
+	for k, v := range log.Fields {
+		labels[k] = v
+	}
+	if len(labels) > 0 {
+		doc["labels"] = labels
+	}
+
+	return json.Marshal(doc)
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Formatter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *formatter) init() *formatter {
+	return o
+}
+
+// set
+// value of dotted key into nested objects, overwrite exists value.
+func (o *formatter) set(doc map[string]interface{}, key string, value interface{}) {
+	keys := strings.Split(key, ".")
+	for _, k := range keys[:len(keys)-1] {
+		m, ok := doc[k].(map[string]interface{})
+		if !ok {
+			m = map[string]interface{}{}
+			doc[k] = m
+		}
+		doc = m
+	}
+	doc[keys[len(keys)-1]] = value
+}
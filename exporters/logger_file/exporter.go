@@ -0,0 +1,177 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_file
+
+import (
+	"context"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/exporters/logfmt"
+	"github.com/fuyibing/log/tracer"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	Exporter interface {
+		Push(log *tracer.Log) error
+		Start(ctx context.Context) error
+		Stopped() bool
+	}
+
+	// Option
+	// custom exporter options.
+	Option func(o *exporter)
+
+	exporter struct {
+		sync.RWMutex
+
+		formatter     Formatter
+		flushInterval time.Duration
+		splits        map[config.LoggerLevel]*writer
+		stopped       bool
+		writer        *writer
+	}
+)
+
+// New
+// returns a file logger exporter, configured by file-logger section
+// of log.yaml.
+func New(opts ...Option) Exporter {
+	o := (&exporter{}).init()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithFormatter
+// use specified formatter, such as logfmt.New().
+func WithFormatter(f Formatter) Option {
+	return func(o *exporter) {
+		if f != nil {
+			o.formatter = f
+		}
+	}
+}
+
+func (o *exporter) Push(log *tracer.Log) (err error) {
+	buf := []byte(o.formatter.Format(log) + "\n")
+
+	if _, err = o.writer.Write(buf); err != nil {
+		return
+	}
+
+	// Write
+	// into separated file of level.
+	if w, ok := o.splits[log.Level]; ok {
+		if _, err = w.Write(buf); err != nil {
+			return
+		}
+	}
+
+	// Flush
+	// immediately if exporter not started.
+	if o.Stopped() {
+		err = o.flush()
+	}
+	return
+}
+
+func (o *exporter) Start(ctx context.Context) error {
+	o.Lock()
+	o.stopped = false
+	o.Unlock()
+
+	ticker := time.NewTicker(o.flushInterval)
+	defer func() {
+		ticker.Stop()
+
+		o.Lock()
+		o.stopped = true
+		o.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = o.flush()
+		case <-ctx.Done():
+			return o.close()
+		}
+	}
+}
+
+func (o *exporter) Stopped() bool {
+	o.RLock()
+	defer o.RUnlock()
+	return o.stopped
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Exporter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *exporter) close() (err error) {
+	for _, w := range o.writers() {
+		if we := w.Close(); we != nil && err == nil {
+			err = we
+		}
+	}
+	return
+}
+
+func (o *exporter) flush() (err error) {
+	for _, w := range o.writers() {
+		if we := w.Flush(); we != nil && err == nil {
+			err = we
+		}
+	}
+	return
+}
+
+func (o *exporter) init() *exporter {
+	c := config.Config.GetFileLogger()
+
+	o.flushInterval = time.Duration(c.GetFlushInterval()) * time.Millisecond
+	o.stopped = true
+
+	switch c.GetFormat() {
+	case config.FormatLogfmt:
+		o.formatter = logfmt.New(c.GetAttributes()...)
+	default:
+		o.formatter = (&formatter{}).init()
+	}
+	o.writer = (&writer{}).init(c, c.GetPath())
+
+	// Separated files
+	// in same directory, named by level.
+	o.splits = make(map[config.LoggerLevel]*writer)
+	for _, level := range c.GetSplitLevels() {
+		path := filepath.Join(filepath.Dir(c.GetPath()), strings.ToLower(level.String())+filepath.Ext(c.GetPath()))
+		o.splits[level] = (&writer{}).init(c, path)
+	}
+	return o
+}
+
+func (o *exporter) writers() []*writer {
+	list := []*writer{o.writer}
+	for _, w := range o.splits {
+		list = append(list, w)
+	}
+	return list
+}
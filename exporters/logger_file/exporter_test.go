@@ -0,0 +1,157 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_file
+
+import (
+	"context"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExporter_Rotate(t *testing.T) {
+	c := config.Config.GetFileLogger()
+	path, maxBackups, compress, levels := c.GetPath(), c.GetMaxBackups(), c.GetCompress(), c.GetSplitLevels()
+	t.Cleanup(func() {
+		config.Config.With(
+			config.FileLoggerPath(path),
+			config.FileLoggerMaxBackups(maxBackups),
+			config.FileLoggerCompress(compress),
+			config.FileLoggerSplitLevels(levels...),
+		)
+	})
+
+	dir := t.TempDir()
+	config.Config.With(
+		config.FileLoggerPath(filepath.Join(dir, "app.log")),
+		config.FileLoggerMaxBackups(2),
+		config.FileLoggerCompress(true),
+		config.FileLoggerSplitLevels("error"),
+	)
+
+	ex := New().(*exporter)
+	ex.writer.maxSize = 256
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- ex.Start(ctx) }()
+
+	for i := 0; i < 40; i++ {
+		x := tracer.NewLog(tracer.LogInternal, config.Info)
+		if i%10 == 0 {
+			x.Level = config.Error
+		}
+		x.Text = strings.Repeat("x", 32)
+		if err := ex.Push(x); err != nil {
+			t.Fatalf("push error: %v", err)
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("stop error: %v", err)
+	}
+
+	backups, _ := ex.writer.backups()
+	if len(backups) != 2 {
+		t.Errorf("expect 2 backups, got %d", len(backups))
+	}
+	for _, info := range backups {
+		if !strings.HasSuffix(info.Name(), ".log.gz") {
+			t.Errorf("backup not compressed: %s", info.Name())
+		}
+	}
+
+	buf, err := os.ReadFile(filepath.Join(dir, "error.log"))
+	if err != nil || strings.Count(string(buf), "\n") != 4 {
+		t.Errorf("expect 4 lines in error.log, got %q, %v", buf, err)
+	}
+}
+
+func TestWriter_RotateTime(t *testing.T) {
+	dir := t.TempDir()
+	w := (&writer{}).init(config.Config.GetFileLogger(), filepath.Join(dir, "time.log"))
+	w.compress = false
+	w.rotateTime = config.RotateDaily
+	w.maxSize = 0
+
+	if _, err := w.Write([]byte("first\n")); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+
+	// Move
+	// period to yesterday.
+	yesterday := time.Now().Add(-time.Hour * 24)
+	w.period = yesterday.Format("20060102")
+	if _, err := w.Write([]byte("second\n")); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+	_ = w.Close()
+
+	if buf, _ := os.ReadFile(filepath.Join(dir, "time.log")); string(buf) != "second\n" {
+		t.Errorf("expect second line only in current file, got %q", buf)
+	}
+
+	// Backup
+	// named with start of yesterday.
+	name := "time-" + yesterday.Format("20060102") + "T000000.000000.log"
+	if buf, err := os.ReadFile(filepath.Join(dir, name)); err != nil || string(buf) != "first\n" {
+		t.Errorf("expect backup %s with first line, got %q, %v", name, buf, err)
+	}
+}
+
+func TestWriter_Backups(t *testing.T) {
+	dir := t.TempDir()
+	w := (&writer{}).init(config.Config.GetFileLogger(), filepath.Join(dir, "app.log"))
+
+	for _, name := range []string{
+		"app.log",
+		"app-20230224T150405.000000.log",
+		"app-20230224T150405.000000-1.log.gz",
+		"app-worker.log",
+		"app-access.log.gz",
+		"app-20230224T150405.000000-x.log",
+		"app-20230224T150405.000000.txt",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatalf("write error: %v", err)
+		}
+	}
+
+	list, err := w.backups()
+	if err != nil {
+		t.Fatalf("backups error: %v", err)
+	}
+
+	// Unrelated
+	// files with same prefix are not backups.
+	names := make([]string, 0, len(list))
+	for _, info := range list {
+		names = append(names, info.Name())
+	}
+	if len(names) != 2 {
+		t.Errorf("expect 2 backups, got %v", names)
+	}
+	for _, name := range names {
+		if !strings.HasPrefix(name, "app-20230224T150405.000000") {
+			t.Errorf("unexpected backup: %s", name)
+		}
+	}
+}
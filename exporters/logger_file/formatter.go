@@ -0,0 +1,48 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_file
+
+import (
+	"fmt"
+	"github.com/fuyibing/log/tracer"
+)
+
+type (
+	Formatter interface {
+		Format(log *tracer.Log) string
+	}
+
+	formatter struct {
+	}
+)
+
+// Format
+// generate log as plain string used in file, without colors.
+func (o *formatter) Format(log *tracer.Log) string {
+	return fmt.Sprintf("[%-26s][%5s] %s",
+		log.Time.Format("2006-01-02 15:04:05.999999"),
+		log.Level.String(),
+		log.Text,
+	)
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Formatter: constructor
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *formatter) init() *formatter {
+	return o
+}
@@ -0,0 +1,374 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_file
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	backupTimeFormat = "20060102T150405.000000"
+	compressSuffix   = ".gz"
+)
+
+type (
+	// writer
+	// a buffered file writer with rotation.
+	writer struct {
+		sync.Mutex
+
+		buf    *bufio.Writer
+		file   *os.File
+		path   string
+		period string
+		size   int64
+
+		bufferSize int
+		compress   bool
+		maxAge     time.Duration
+		maxBackups int
+		maxSize    int64
+		rotateTime config.RotateTime
+
+		mill     sync.Mutex
+		millWait sync.WaitGroup
+	}
+)
+
+// Close
+// flush buffer and close file, waiting for cleanup finished.
+func (o *writer) Close() (err error) {
+	o.Lock()
+	err = o.close()
+	o.Unlock()
+
+	o.millWait.Wait()
+	return
+}
+
+// Flush
+// write buffered data into file.
+func (o *writer) Flush() error {
+	o.Lock()
+	defer o.Unlock()
+
+	if o.buf != nil {
+		return o.buf.Flush()
+	}
+	return nil
+}
+
+// Write
+// data into buffer, file rotated if size or time limit reached.
+func (o *writer) Write(p []byte) (n int, err error) {
+	o.Lock()
+	defer o.Unlock()
+
+	now := time.Now()
+
+	// Open
+	// file on first write.
+	if o.file == nil {
+		if err = o.open(now); err != nil {
+			return
+		}
+	}
+
+	// Rotate
+	// if size or time limit reached.
+	if (o.maxSize > 0 && o.size > 0 && o.size+int64(len(p)) > o.maxSize) || o.period != o.periodOf(now) {
+		if err = o.rotate(now); err != nil {
+			return
+		}
+	}
+
+	n, err = o.buf.Write(p)
+	o.size += int64(n)
+	return
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Writer: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// backupName
+// returns a file name for rotated file, such as app-20230224T150405.000000.log.
+func (o *writer) backupName(t time.Time) string {
+	var (
+		dir  = filepath.Dir(o.path)
+		ext  = filepath.Ext(o.path)
+		name = strings.TrimSuffix(filepath.Base(o.path), ext)
+		str  = filepath.Join(dir, fmt.Sprintf("%s-%s%s", name, t.Format(backupTimeFormat), ext))
+	)
+
+	// Avoid
+	// conflict with exists file.
+	for i := 1; ; i++ {
+		if _, err := os.Stat(str); os.IsNotExist(err) {
+			if _, err = os.Stat(str + compressSuffix); os.IsNotExist(err) {
+				return str
+			}
+		}
+		str = filepath.Join(dir, fmt.Sprintf("%s-%s-%d%s", name, t.Format(backupTimeFormat), i, ext))
+	}
+}
+
+// backups
+// returns rotated files, the newest first.
+func (o *writer) backups() (list []os.FileInfo, err error) {
+	var (
+		dir     = filepath.Dir(o.path)
+		ext     = filepath.Ext(o.path)
+		entries []os.DirEntry
+		prefix  = strings.TrimSuffix(filepath.Base(o.path), ext) + "-"
+	)
+
+	if entries, err = os.ReadDir(dir); err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !isBackup(entry.Name(), prefix, ext) {
+			continue
+		}
+		if info, ie := entry.Info(); ie == nil {
+			list = append(list, info)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].ModTime().After(list[j].ModTime()) })
+	return
+}
+
+func (o *writer) close() (err error) {
+	if o.file == nil {
+		return
+	}
+
+	if err = o.buf.Flush(); err == nil {
+		err = o.file.Close()
+	} else {
+		_ = o.file.Close()
+	}
+
+	o.buf = nil
+	o.file = nil
+	return
+}
+
+// gzip
+// compress file and remove source.
+func (o *writer) gzip(src string) (err error) {
+	var (
+		dst      = src + compressSuffix
+		info     os.FileInfo
+		in, out  *os.File
+		compress *gzip.Writer
+	)
+
+	if info, err = os.Stat(src); err != nil {
+		return
+	}
+	if in, err = os.Open(src); err != nil {
+		return
+	}
+	defer func() { _ = in.Close() }()
+
+	if out, err = os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode()); err != nil {
+		return
+	}
+
+	compress = gzip.NewWriter(out)
+	if _, err = io.Copy(compress, in); err == nil {
+		err = compress.Close()
+	}
+	if ce := out.Close(); err == nil {
+		err = ce
+	}
+
+	if err != nil {
+		_ = os.Remove(dst)
+		return
+	}
+
+	// Keep
+	// modified time of source, used to sort backups.
+	_ = os.Chtimes(dst, info.ModTime(), info.ModTime())
+	return os.Remove(src)
+}
+
+func (o *writer) init(c config.FileLoggerConfiguration, path string) *writer {
+	o.bufferSize = c.GetBufferSize()
+	o.compress = c.GetCompress()
+	o.maxAge = time.Duration(c.GetMaxAge()) * time.Hour * 24
+	o.maxBackups = c.GetMaxBackups()
+	o.maxSize = int64(c.GetMaxSize()) * 1024 * 1024
+	o.path = path
+	o.rotateTime = c.GetRotateTime()
+	return o
+}
+
+// millRun
+// remove expired backups and compress others.
+func (o *writer) millRun() {
+	o.mill.Lock()
+	defer o.mill.Unlock()
+
+	list, err := o.backups()
+	if err != nil {
+		return
+	}
+
+	expired := time.Now().Add(-o.maxAge)
+	for i, info := range list {
+		path := filepath.Join(filepath.Dir(o.path), info.Name())
+
+		// Remove
+		// if out of backups or age.
+		if (o.maxBackups > 0 && i >= o.maxBackups) || (o.maxAge > 0 && info.ModTime().Before(expired)) {
+			_ = os.Remove(path)
+			continue
+		}
+
+		if o.compress && !strings.HasSuffix(path, compressSuffix) {
+			_ = o.gzip(path)
+		}
+	}
+}
+
+func (o *writer) open(now time.Time) (err error) {
+	var info os.FileInfo
+
+	if err = os.MkdirAll(filepath.Dir(o.path), 0755); err != nil {
+		return
+	}
+	if o.file, err = os.OpenFile(o.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644); err != nil {
+		return
+	}
+
+	// Continue
+	// with exists file.
+	o.period = o.periodOf(now)
+	o.size = 0
+	if info, err = o.file.Stat(); err == nil {
+		o.period = o.periodOf(info.ModTime())
+		o.size = info.Size()
+	}
+
+	o.buf = bufio.NewWriterSize(o.file, o.bufferSize)
+	return nil
+}
+
+// periodLayout
+// returns a layout of period key, empty if rotate time disabled.
+func (o *writer) periodLayout() string {
+	switch o.rotateTime {
+	case config.RotateHourly:
+		return "2006010215"
+	case config.RotateDaily:
+		return "20060102"
+	}
+	return ""
+}
+
+// periodOf
+// returns a period key of time.
+func (o *writer) periodOf(t time.Time) string {
+	if layout := o.periodLayout(); layout != "" {
+		return t.Format(layout)
+	}
+	return ""
+}
+
+func (o *writer) rotate(now time.Time) (err error) {
+	if err = o.close(); err != nil {
+		return
+	}
+
+	// Backup
+	// named with start of period the file covers, or rotation time
+	// if rotated by size.
+	t := now
+	if o.period != o.periodOf(now) {
+		if start, pe := time.ParseInLocation(o.periodLayout(), o.period, now.Location()); pe == nil {
+			t = start
+		}
+	}
+
+	if err = os.Rename(o.path, o.backupName(t)); err != nil && !os.IsNotExist(err) {
+		return
+	}
+	if err = o.open(now); err != nil {
+		return
+	}
+
+	// Reset
+	// state of new file.
+	o.period = o.periodOf(now)
+	o.size = 0
+
+	o.millWait.Add(1)
+	go func() {
+		defer o.millWait.Done()
+		o.millRun()
+	}()
+	return
+}
+
+// isBackup
+// return true if name is a rotated file name, prefix and extension of
+// log file with backup time, optional sequence and compress suffix.
+//
+//	app-20230224T150405.000000.log
+//	app-20230224T150405.000000-1.log.gz
+func isBackup(name, prefix, ext string) bool {
+	name = strings.TrimSuffix(name, compressSuffix)
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+		return false
+	}
+
+	str := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+	if len(str) < len(backupTimeFormat) {
+		return false
+	}
+	if _, err := time.Parse(backupTimeFormat, str[:len(backupTimeFormat)]); err != nil {
+		return false
+	}
+
+	// Sequence
+	// appended if conflict.
+	if seq := str[len(backupTimeFormat):]; seq != "" {
+		if len(seq) < 2 || seq[0] != '-' {
+			return false
+		}
+		for _, c := range seq[1:] {
+			if c < '0' || c > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
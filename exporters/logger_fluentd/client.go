@@ -0,0 +1,105 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_fluentd
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+type (
+	// client
+	// send forward messages on a connection, connection is closed if
+	// error occurred and reopened on next send.
+	client struct {
+		sync.Mutex
+
+		address    string
+		conn       net.Conn
+		network    string
+		reader     *bufio.Reader
+		requireAck bool
+		timeout    time.Duration
+	}
+)
+
+// Close
+// close connection.
+func (o *client) Close() error {
+	o.Lock()
+	defer o.Unlock()
+
+	if o.conn != nil {
+		err := o.conn.Close()
+		o.conn = nil
+		return err
+	}
+	return nil
+}
+
+// Send
+// write message and wait for ack of chunk id if required.
+func (o *client) Send(msg []byte, chunk string) (err error) {
+	o.Lock()
+	defer o.Unlock()
+
+	if o.conn == nil {
+		if o.conn, err = net.DialTimeout(o.network, o.address, o.timeout); err != nil {
+			return
+		}
+		o.reader = bufio.NewReader(o.conn)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = o.conn.Close()
+			o.conn = nil
+		}
+	}()
+
+	if err = o.conn.SetWriteDeadline(time.Now().Add(o.timeout)); err != nil {
+		return
+	}
+	if _, err = o.conn.Write(msg); err != nil || !o.requireAck {
+		return
+	}
+
+	// Ack
+	// responded with same chunk id.
+	var ack string
+	if err = o.conn.SetReadDeadline(time.Now().Add(o.timeout)); err != nil {
+		return
+	}
+	if ack, err = decodeAck(o.reader); err == nil && ack != chunk {
+		err = fmt.Errorf("fluentd: unexpected ack %q of chunk %q", ack, chunk)
+	}
+	return
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Client: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *client) init(network, address string, timeout time.Duration, requireAck bool) *client {
+	o.address = address
+	o.network = network
+	o.requireAck = requireAck
+	o.timeout = timeout
+	return o
+}
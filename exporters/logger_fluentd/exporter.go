@@ -0,0 +1,152 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_fluentd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/exporters/batch"
+	"github.com/fuyibing/log/tracer"
+	"time"
+)
+
+type (
+	Exporter interface {
+		Push(log *tracer.Log) error
+		Start(ctx context.Context) error
+		Stopped() bool
+	}
+
+	// Option
+	// custom exporter options.
+	Option func(o *exporter)
+
+	exporter struct {
+		batcher      batch.Batcher
+		client       *client
+		formatter    Formatter
+		requireAck   bool
+		retries      int
+		retryBackoff time.Duration
+		tag          string
+	}
+)
+
+// New
+// returns a fluentd logger exporter, configured by fluentd-logger
+// section of log.yaml.
+func New(opts ...Option) Exporter {
+	o := (&exporter{}).init()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithFormatter
+// use specified formatter.
+func WithFormatter(f Formatter) Option {
+	return func(o *exporter) {
+		if f != nil {
+			o.formatter = f
+		}
+	}
+}
+
+// Push
+// log into batch buffer, buffer sent immediately if exporter is not
+// started.
+func (o *exporter) Push(log *tracer.Log) error { return o.batcher.Push(log) }
+
+func (o *exporter) Start(ctx context.Context) error {
+	err := o.batcher.Start(ctx)
+	_ = o.client.Close()
+	return err
+}
+
+func (o *exporter) Stopped() bool { return o.batcher.Stopped() }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Exporter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// encode
+// returns a message in packed forward mode.
+//
+//	[tag, <entries>, {"size": n, "chunk": id}]
+//
+// Entries is a binary of concatenated [time, record] arrays.
+func (o *exporter) encode(logs []*tracer.Log, chunk string) []byte {
+	entries := &bytes.Buffer{}
+	for _, log := range logs {
+		encodeArrayHeader(entries, 2)
+		encode(entries, EventTime(log.Time))
+		encode(entries, o.formatter.Format(log))
+	}
+
+	option := map[string]interface{}{"size": len(logs)}
+	if chunk != "" {
+		option["chunk"] = chunk
+	}
+
+	msg := &bytes.Buffer{}
+	encodeArrayHeader(msg, 3)
+	encodeString(msg, o.tag)
+	encodeBinary(msg, entries.Bytes())
+	encode(msg, option)
+	return msg.Bytes()
+}
+
+func (o *exporter) init() *exporter {
+	c := config.Config.GetFluentdLogger()
+
+	o.batcher = batch.New("fluentd logger", c.GetBatchSize(), time.Duration(c.GetBatchTimeout())*time.Millisecond, o.send)
+	o.client = (&client{}).init(c.GetNetwork(), c.GetAddress(), time.Duration(c.GetTimeout())*time.Millisecond, c.GetRequireAck())
+	o.formatter = (&formatter{}).init()
+	o.requireAck = c.GetRequireAck()
+	o.retries = c.GetRetries()
+	o.retryBackoff = time.Duration(c.GetRetryBackoff()) * time.Millisecond
+	o.tag = c.GetTag()
+	return o
+}
+
+// send
+// forward logs, message resent with same chunk id if connection
+// failed or ack not received, fluentd may receive it more than once.
+func (o *exporter) send(logs []*tracer.Log) (err error) {
+	var chunk string
+	if o.requireAck {
+		buf := make([]byte, 16)
+		_, _ = rand.Read(buf)
+		chunk = base64.StdEncoding.EncodeToString(buf)
+	}
+
+	msg := o.encode(logs, chunk)
+	backoff := o.retryBackoff
+	for attempt := 0; attempt <= o.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		if err = o.client.Send(msg, chunk); err == nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,292 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_fluentd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"io"
+	"math"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type forwardMessage struct {
+	tag     string
+	entries [][]interface{}
+	option  map[string]interface{}
+}
+
+func TestExporter_Ack(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen error: %v", err)
+	}
+	defer func() { _ = l.Close() }()
+
+	// Server
+	// first connection closed without ack, message resent on second
+	// connection and acknowledged.
+	received := make(chan *forwardMessage, 2)
+	go func() {
+		for i := 0; i < 2; i++ {
+			conn, ae := l.Accept()
+			if ae != nil {
+				return
+			}
+			msg, de := readMessage(bufio.NewReader(conn))
+			if de != nil {
+				t.Errorf("decode error: %v", de)
+				_ = conn.Close()
+				return
+			}
+			received <- msg
+
+			if i == 1 {
+				ack := &bytes.Buffer{}
+				encode(ack, map[string]interface{}{"ack": msg.option["chunk"]})
+				_, _ = conn.Write(ack.Bytes())
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	config.Config.With(config.FluentdAddress("tcp", l.Addr().String()))
+
+	o := New().(*exporter)
+	o.retries = 2
+	o.retryBackoff = time.Millisecond
+
+	x := tracer.NewLog(tracer.LogSpan, config.Warn)
+	x.Text = "disk almost full"
+	x.Time = time.Unix(1677222245, 123456789)
+	x.TraceId = tracer.Identify.NewTraceId()
+	x.SpanId = tracer.Identify.NewSpanId()
+	x.Fields = tracer.Attr{"usage": 0.95, "count": -3}
+	x.Attr = tracer.Attr{"service.name": "fluentd-test"}
+
+	if err = o.send([]*tracer.Log{x, tracer.NewLog(tracer.LogSpan, config.Info)}); err != nil {
+		t.Fatalf("send error: %v", err)
+	}
+
+	m1, m2 := <-received, <-received
+	if m1.option["chunk"] == nil || m1.option["chunk"] != m2.option["chunk"] {
+		t.Errorf("expect same chunk, got %v and %v", m1.option["chunk"], m2.option["chunk"])
+	}
+	if m2.tag != config.Config.GetTracerTopic() || m2.option["size"] != int64(2) || len(m2.entries) != 2 {
+		t.Fatalf("unexpected message: %s %v %d", m2.tag, m2.option, len(m2.entries))
+	}
+
+	if ts := m2.entries[0][0].(time.Time); !ts.Equal(x.Time) {
+		t.Errorf("unexpected time: %v", ts)
+	}
+
+	record := m2.entries[0][1].(map[string]interface{})
+	for k, v := range map[string]interface{}{
+		"level":        "warn",
+		"message":      "disk almost full",
+		"trace_id":     x.TraceId.String(),
+		"span_id":      x.SpanId.String(),
+		"service.name": "fluentd-test",
+		"usage":        0.95,
+		"count":        int64(-3),
+	} {
+		if record[k] != v {
+			t.Errorf("record %s: expect %v, got %v", k, v, record[k])
+		}
+	}
+}
+
+func TestExporter_Unix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fluentd.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("listen error: %v", err)
+	}
+	defer func() { _ = l.Close() }()
+
+	received := make(chan *forwardMessage, 1)
+	go func() {
+		conn, ae := l.Accept()
+		if ae != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		if msg, de := readMessage(bufio.NewReader(conn)); de == nil {
+			received <- msg
+		}
+	}()
+
+	config.Config.With(config.FluentdAddress("unix", path))
+
+	o := New().(*exporter)
+	o.requireAck = false
+	o.client.requireAck = false
+
+	if err = o.Push(tracer.NewLog(tracer.LogSpan, config.Info)); err != nil {
+		t.Fatalf("push error: %v", err)
+	}
+
+	select {
+	case msg := <-received:
+		if _, ok := msg.option["chunk"]; ok || len(msg.entries) != 1 {
+			t.Errorf("unexpected message: %v", msg.option)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message not received")
+	}
+}
+
+func TestEncode(t *testing.T) {
+	for _, v := range []interface{}{
+		nil, true, "short", string(make([]byte, 300)), int64(0), int64(127), int64(-32), int64(-33),
+		int64(-200), int64(-40000), int64(math.MinInt64), int64(255), int64(70000), int64(math.MaxInt64),
+	} {
+		buf := &bytes.Buffer{}
+		encode(buf, v)
+
+		x, err := readValue(bufio.NewReader(buf))
+		if err != nil || x != v {
+			t.Errorf("encode %v: got %v, error %v", v, x, err)
+		}
+	}
+}
+
+// readMessage
+// decode a packed forward message.
+func readMessage(r *bufio.Reader) (msg *forwardMessage, err error) {
+	var v interface{}
+	if v, err = readValue(r); err != nil {
+		return
+	}
+
+	arr, ok := v.([]interface{})
+	if !ok || len(arr) != 3 {
+		return nil, fmt.Errorf("unexpected message: %v", v)
+	}
+
+	msg = &forwardMessage{tag: arr[0].(string), option: arr[2].(map[string]interface{})}
+	entries := bufio.NewReader(bytes.NewReader(arr[1].([]byte)))
+	for {
+		if v, err = readValue(entries); err == io.EOF {
+			return msg, nil
+		} else if err != nil {
+			return
+		}
+		msg.entries = append(msg.entries, v.([]interface{}))
+	}
+}
+
+// readValue
+// decode a msgpack value.
+func readValue(r *bufio.Reader) (interface{}, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	read := func(n int) []byte {
+		buf := make([]byte, n)
+		if _, re := io.ReadFull(r, buf); re != nil {
+			err = re
+		}
+		return buf
+	}
+	size := func(n int) int {
+		var v uint64
+		for _, x := range read(n) {
+			v = v<<8 | uint64(x)
+		}
+		return int(v)
+	}
+
+	var (
+		n    int
+		kind byte
+	)
+
+	switch {
+	case b <= 0x7f:
+		return int64(b), nil
+	case b >= 0xe0:
+		return int64(int8(b)), nil
+	case b == 0xc0:
+		return nil, nil
+	case b == 0xc2, b == 0xc3:
+		return b == 0xc3, nil
+	case b == 0xca:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(read(4)))), err
+	case b == 0xcb:
+		return math.Float64frombits(binary.BigEndian.Uint64(read(8))), err
+	case b >= 0xcc && b <= 0xcf:
+		return int64(size(1 << (b - 0xcc))), err
+	case b == 0xd0:
+		return int64(int8(read(1)[0])), err
+	case b == 0xd1:
+		return int64(int16(binary.BigEndian.Uint16(read(2)))), err
+	case b == 0xd2:
+		return int64(int32(binary.BigEndian.Uint32(read(4)))), err
+	case b == 0xd3:
+		return int64(binary.BigEndian.Uint64(read(8))), err
+	case b == 0xd7:
+		buf := read(9)
+		return time.Unix(int64(binary.BigEndian.Uint32(buf[1:5])), int64(binary.BigEndian.Uint32(buf[5:]))), err
+	case b&0xe0 == 0xa0:
+		return string(read(int(b & 0x1f))), err
+	case b == 0xd9, b == 0xda, b == 0xdb:
+		return string(read(size(1 << (b - 0xd9)))), err
+	case b == 0xc4, b == 0xc5, b == 0xc6:
+		return read(size(1 << (b - 0xc4))), err
+	case b&0xf0 == 0x90:
+		n, kind = int(b&0x0f), 'a'
+	case b == 0xdc, b == 0xdd:
+		n, kind = size(2<<(b-0xdc)), 'a'
+	case b&0xf0 == 0x80:
+		n, kind = int(b&0x0f), 'm'
+	case b == 0xde, b == 0xdf:
+		n, kind = size(2<<(b-0xde)), 'm'
+	default:
+		return nil, fmt.Errorf("unsupported type 0x%02x", b)
+	}
+
+	if kind == 'a' {
+		arr := make([]interface{}, n)
+		for i := range arr {
+			if arr[i], err = readValue(r); err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	}
+
+	m := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		k, ke := readValue(r)
+		if ke != nil {
+			return nil, ke
+		}
+		if m[k.(string)], err = readValue(r); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,65 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_fluentd
+
+import (
+	"github.com/fuyibing/log/tracer"
+	"strings"
+)
+
+type (
+	Formatter interface {
+		Format(log *tracer.Log) map[string]interface{}
+	}
+
+	formatter struct {
+	}
+)
+
+// Format
+// generate record of fluentd event. Provider attributes and log fields
+// merged into record, overwritten by reserved keys: level, message,
+// trace_id, span_id and caller.
+func (o *formatter) Format(log *tracer.Log) map[string]interface{} {
+	record := make(map[string]interface{})
+
+	for k, v := range log.Attr {
+		record[k] = v
+	}
+	for k, v := range log.Fields {
+		record[k] = v
+	}
+
+	record["level"] = strings.ToLower(log.Level.String())
+	record["message"] = log.Text
+
+	if !log.TraceId.IsZero() {
+		record["trace_id"] = log.TraceId.String()
+		record["span_id"] = log.SpanId.String()
+	}
+	if log.Caller != "" {
+		record["caller"] = log.Caller
+	}
+	return record
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Formatter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *formatter) init() *formatter {
+	return o
+}
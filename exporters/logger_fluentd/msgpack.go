@@ -0,0 +1,336 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_fluentd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+type (
+	// EventTime
+	// a nanosecond precision time, encoded as msgpack extension
+	// type 0 of forward protocol.
+	EventTime time.Time
+)
+
+// encode
+// value as msgpack, unknown types encoded as string.
+func encode(buf *bytes.Buffer, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteByte(0xc0)
+	case bool:
+		if v {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case string:
+		encodeString(buf, v)
+	case []byte:
+		encodeBinary(buf, v)
+	case int:
+		encodeInt(buf, int64(v))
+	case int8:
+		encodeInt(buf, int64(v))
+	case int16:
+		encodeInt(buf, int64(v))
+	case int32:
+		encodeInt(buf, int64(v))
+	case int64:
+		encodeInt(buf, v)
+	case uint:
+		encodeUint(buf, uint64(v))
+	case uint8:
+		encodeUint(buf, uint64(v))
+	case uint16:
+		encodeUint(buf, uint64(v))
+	case uint32:
+		encodeUint(buf, uint64(v))
+	case uint64:
+		encodeUint(buf, v)
+	case float32:
+		buf.WriteByte(0xca)
+		_ = binary.Write(buf, binary.BigEndian, math.Float32bits(v))
+	case float64:
+		buf.WriteByte(0xcb)
+		_ = binary.Write(buf, binary.BigEndian, math.Float64bits(v))
+	case EventTime:
+		t := time.Time(v)
+		buf.Write([]byte{0xd7, 0x00})
+		_ = binary.Write(buf, binary.BigEndian, uint32(t.Unix()))
+		_ = binary.Write(buf, binary.BigEndian, uint32(t.Nanosecond()))
+	case time.Time:
+		encodeString(buf, v.Format(time.RFC3339Nano))
+	case error:
+		encodeString(buf, v.Error())
+	case fmt.Stringer:
+		encodeString(buf, v.String())
+	case map[string]interface{}:
+		encodeMap(buf, v)
+	case map[string]string:
+		m := make(map[string]interface{}, len(v))
+		for k, s := range v {
+			m[k] = s
+		}
+		encodeMap(buf, m)
+	case []interface{}:
+		encodeArrayHeader(buf, len(v))
+		for _, x := range v {
+			encode(buf, x)
+		}
+	case []string:
+		encodeArrayHeader(buf, len(v))
+		for _, x := range v {
+			encodeString(buf, x)
+		}
+	default:
+		encodeString(buf, fmt.Sprintf("%v", v))
+	}
+}
+
+func encodeArrayHeader(buf *bytes.Buffer, n int) {
+	switch {
+	case n < 16:
+		buf.WriteByte(0x90 | byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xdc)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdd)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+}
+
+func encodeBinary(buf *bytes.Buffer, b []byte) {
+	switch n := len(b); {
+	case n <= math.MaxUint8:
+		buf.Write([]byte{0xc4, byte(n)})
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xc5)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xc6)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+	buf.Write(b)
+}
+
+func encodeInt(buf *bytes.Buffer, v int64) {
+	switch {
+	case v >= 0:
+		encodeUint(buf, uint64(v))
+	case v >= -32:
+		buf.WriteByte(byte(v))
+	case v >= math.MinInt8:
+		buf.Write([]byte{0xd0, byte(v)})
+	case v >= math.MinInt16:
+		buf.WriteByte(0xd1)
+		_ = binary.Write(buf, binary.BigEndian, int16(v))
+	case v >= math.MinInt32:
+		buf.WriteByte(0xd2)
+		_ = binary.Write(buf, binary.BigEndian, int32(v))
+	default:
+		buf.WriteByte(0xd3)
+		_ = binary.Write(buf, binary.BigEndian, v)
+	}
+}
+
+// encodeMap
+// keys sorted for deterministic output.
+func encodeMap(buf *bytes.Buffer, m map[string]interface{}) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	switch n := len(keys); {
+	case n < 16:
+		buf.WriteByte(0x80 | byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xde)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdf)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+
+	for _, k := range keys {
+		encodeString(buf, k)
+		encode(buf, m[k])
+	}
+}
+
+func encodeString(buf *bytes.Buffer, s string) {
+	switch n := len(s); {
+	case n < 32:
+		buf.WriteByte(0xa0 | byte(n))
+	case n <= math.MaxUint8:
+		buf.Write([]byte{0xd9, byte(n)})
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xda)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdb)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+	buf.WriteString(s)
+}
+
+func encodeUint(buf *bytes.Buffer, v uint64) {
+	switch {
+	case v < 128:
+		buf.WriteByte(byte(v))
+	case v <= math.MaxUint8:
+		buf.Write([]byte{0xcc, byte(v)})
+	case v <= math.MaxUint16:
+		buf.WriteByte(0xcd)
+		_ = binary.Write(buf, binary.BigEndian, uint16(v))
+	case v <= math.MaxUint32:
+		buf.WriteByte(0xce)
+		_ = binary.Write(buf, binary.BigEndian, uint32(v))
+	default:
+		buf.WriteByte(0xcf)
+		_ = binary.Write(buf, binary.BigEndian, v)
+	}
+}
+
+// decodeAck
+// read ack response of forward protocol, a map such as {"ack": "id"}.
+// Returns value of ack key.
+func decodeAck(r *bufio.Reader) (ack string, err error) {
+	var (
+		n   int
+		key string
+		val interface{}
+	)
+
+	if n, err = decodeMapHeader(r); err != nil {
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		if key, err = decodeString(r); err != nil {
+			return
+		}
+		if val, err = decodeScalar(r); err != nil {
+			return
+		}
+		if s, ok := val.(string); ok && key == "ack" {
+			ack = s
+		}
+	}
+	return
+}
+
+func decodeMapHeader(r *bufio.Reader) (int, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case b&0xf0 == 0x80:
+		return int(b & 0x0f), nil
+	case b == 0xde:
+		var n uint16
+		err = binary.Read(r, binary.BigEndian, &n)
+		return int(n), err
+	case b == 0xdf:
+		var n uint32
+		err = binary.Read(r, binary.BigEndian, &n)
+		return int(n), err
+	}
+	return 0, fmt.Errorf("msgpack: expect map, got 0x%02x", b)
+}
+
+// decodeScalar
+// read a nil, bool, integer, string or binary value.
+func decodeScalar(r *bufio.Reader) (interface{}, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	var size int
+	switch {
+	case b <= 0x7f || b >= 0xe0:
+		return int64(int8(b)), nil
+	case b == 0xc0:
+		return nil, nil
+	case b == 0xc2 || b == 0xc3:
+		return b == 0xc3, nil
+	case b&0xe0 == 0xa0:
+		size = int(b & 0x1f)
+	case b == 0xd9 || b == 0xc4:
+		var n uint8
+		err = binary.Read(r, binary.BigEndian, &n)
+		size = int(n)
+	case b == 0xda || b == 0xc5:
+		var n uint16
+		err = binary.Read(r, binary.BigEndian, &n)
+		size = int(n)
+	case b == 0xdb || b == 0xc6:
+		var n uint32
+		err = binary.Read(r, binary.BigEndian, &n)
+		size = int(n)
+	case b >= 0xcc && b <= 0xd3:
+		buf := make([]byte, 1<<((b-0xcc)%4))
+		if _, err = io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		var v uint64
+		for _, x := range buf {
+			v = v<<8 | uint64(x)
+		}
+		// Sign
+		// extend int8 ... int64.
+		if bits := uint(len(buf) * 8); b >= 0xd0 && bits < 64 && v>>(bits-1) == 1 {
+			v |= math.MaxUint64 << bits
+		}
+		return int64(v), nil
+	default:
+		return nil, fmt.Errorf("msgpack: unsupported type 0x%02x", b)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, size)
+	if _, err = io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	return string(buf), nil
+}
+
+func decodeString(r *bufio.Reader) (string, error) {
+	v, err := decodeScalar(r)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	return "", errors.New("msgpack: expect string")
+}
@@ -0,0 +1,90 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_gelf
+
+import (
+	"context"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"time"
+)
+
+type (
+	Exporter interface {
+		Push(log *tracer.Log) error
+		Start(ctx context.Context) error
+		Stopped() bool
+	}
+
+	// Option
+	// custom exporter options.
+	Option func(o *exporter)
+
+	exporter struct {
+		formatter Formatter
+		writer    *writer
+	}
+)
+
+// New
+// returns a gelf logger exporter, configured by gelf-logger section
+// of log.yaml.
+func New(opts ...Option) Exporter {
+	o := (&exporter{}).init()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithFormatter
+// use specified formatter.
+func WithFormatter(f Formatter) Option {
+	return func(o *exporter) {
+		if f != nil {
+			o.formatter = f
+		}
+	}
+}
+
+// Push
+// send log to graylog, returns an error if connection is not
+// available.
+func (o *exporter) Push(log *tracer.Log) error {
+	msg, err := o.formatter.Format(log)
+	if err != nil {
+		return err
+	}
+	return o.writer.Write(msg)
+}
+
+func (o *exporter) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return o.writer.Close()
+}
+
+func (o *exporter) Stopped() bool { return true }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Exporter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *exporter) init() *exporter {
+	c := config.Config.GetGelfLogger()
+	o.formatter = (&formatter{}).init(c)
+	o.writer = (&writer{}).init(c.GetNetwork(), c.GetAddress(), c.GetChunkSize(), c.GetCompression(), time.Duration(c.GetTimeout())*time.Millisecond)
+	return o
+}
@@ -0,0 +1,203 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_gelf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExporter_UdpChunked(t *testing.T) {
+	for _, compression := range []string{"gzip", "zlib", "none"} {
+		pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("listen error: %v", err)
+		}
+
+		config.Config.With(config.GelfAddress("udp", pc.LocalAddr().String()), config.GelfCompression(compression))
+
+		// Text
+		// random and large enough to be chunked after compression.
+		text := make([]byte, 8000)
+		for i := range text {
+			text[i] = byte('a' + rand.Intn(26))
+		}
+
+		x := tracer.NewLog(tracer.LogSpan, config.Error)
+		x.Text = "request failed\n" + string(text)
+		x.TraceId = tracer.Identify.NewTraceId()
+		x.SpanId = tracer.Identify.NewSpanId()
+		x.Fields = tracer.Attr{"id": 1, "user name": "jack"}
+		x.Attr = tracer.Attr{"service.name": "gelf-test"}
+
+		if err = New().Push(x); err != nil {
+			t.Fatalf("push error: %v", err)
+		}
+
+		msg := receive(t, pc, compression)
+		_ = pc.Close()
+
+		for k, v := range map[string]interface{}{
+			"version":       "1.1",
+			"short_message": "request failed",
+			"full_message":  x.Text,
+			"level":         float64(3),
+			"_trace_id":     x.TraceId.String(),
+			"_span_id":      x.SpanId.String(),
+			"_service.name": "gelf-test",
+			"_id_":          float64(1),
+			"_user_name":    "jack",
+		} {
+			if msg[k] != v {
+				t.Errorf("%s: field %s: expect %v, got %v", compression, k, v, msg[k])
+			}
+		}
+	}
+}
+
+func TestExporter_Tcp(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen error: %v", err)
+	}
+	defer func() { _ = l.Close() }()
+
+	received := make(chan string, 2)
+	go func() {
+		conn, ae := l.Accept()
+		if ae != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		r := bufio.NewReader(conn)
+		for i := 0; i < 2; i++ {
+			s, re := r.ReadString(0)
+			if re != nil {
+				return
+			}
+			received <- strings.TrimSuffix(s, "\x00")
+		}
+	}()
+
+	config.Config.With(config.GelfAddress("tcp", l.Addr().String()))
+	o := New()
+
+	for _, text := range []string{"first", "second"} {
+		x := tracer.NewLog(tracer.LogSpan, config.Info)
+		x.Text = text
+		if err = o.Push(x); err != nil {
+			t.Fatalf("push error: %v", err)
+		}
+	}
+
+	for _, text := range []string{"first", "second"} {
+		var msg map[string]interface{}
+		select {
+		case s := <-received:
+			if err = json.Unmarshal([]byte(s), &msg); err != nil {
+				t.Fatalf("decode error: %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("message not received")
+		}
+		if msg["short_message"] != text || msg["level"] != float64(6) {
+			t.Errorf("unexpected message: %v", msg)
+		}
+	}
+}
+
+func TestField(t *testing.T) {
+	for k, f := range map[string]string{
+		"service.name": "_service.name",
+		"user id":      "_user_id",
+		"id":           "_id_",
+	} {
+		if v := Field(k); v != f {
+			t.Errorf("field of %q: expect %q, got %q", k, f, v)
+		}
+	}
+}
+
+// receive
+// read datagrams until a message reassembled.
+func receive(t *testing.T, pc net.PacketConn, compression string) map[string]interface{} {
+	var (
+		buf    = make([]byte, 65536)
+		chunks = map[byte][]byte{}
+		count  = 0
+		data   []byte
+	)
+
+	for data == nil {
+		_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("read error: %v", err)
+		}
+		if n > 1420 {
+			t.Fatalf("datagram of %d bytes exceeds chunk size", n)
+		}
+
+		p := append([]byte(nil), buf[:n]...)
+		if !bytes.HasPrefix(p, chunkMagic) {
+			data = p
+			break
+		}
+
+		chunks[p[10]], count = p[12:], int(p[11])
+		if len(chunks) == count {
+			for i := 0; i < count; i++ {
+				data = append(data, chunks[byte(i)]...)
+			}
+		}
+	}
+
+	if count < 2 && compression != "none" {
+		t.Errorf("%s: expect chunked message", compression)
+	}
+
+	var (
+		r   io.Reader = bytes.NewReader(data)
+		err error
+	)
+	switch compression {
+	case "gzip":
+		r, err = gzip.NewReader(r)
+	case "zlib":
+		r, err = zlib.NewReader(r)
+	}
+	if err != nil {
+		t.Fatalf("%s: decompress error: %v", compression, err)
+	}
+
+	msg := map[string]interface{}{}
+	if err = json.NewDecoder(r).Decode(&msg); err != nil {
+		t.Fatalf("%s: decode error: %v", compression, err)
+	}
+	return msg
+}
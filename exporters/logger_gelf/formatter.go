@@ -0,0 +1,131 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_gelf
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/exporters/severity"
+	"github.com/fuyibing/log/tracer"
+	"strings"
+)
+
+type (
+	Formatter interface {
+		Format(log *tracer.Log) ([]byte, error)
+	}
+
+	formatter struct {
+		host string
+	}
+)
+
+// Format
+// generate log as gelf 1.1 message. First line of text used as short
+// message, full message included if text has more lines. Provider
+// attributes and log fields sent as additional fields.
+//
+//	{"version":"1.1","host":"web-1","short_message":"user login","timestamp":1677222245.123,"level":6,"_trace_id":"..."}
+func (o *formatter) Format(log *tracer.Log) ([]byte, error) {
+	msg := map[string]interface{}{
+		"version":   "1.1",
+		"host":      o.host,
+		"timestamp": float64(log.Time.UnixNano()/1e6) / 1e3,
+		"level":     severity.Syslog(log.Level),
+	}
+
+	if i := strings.IndexByte(log.Text, '\n'); i >= 0 {
+		msg["short_message"] = strings.TrimRight(log.Text[:i], "\r")
+		msg["full_message"] = log.Text
+	} else {
+		msg["short_message"] = log.Text
+	}
+	if msg["short_message"] == "" {
+		msg["short_message"] = "-"
+	}
+
+	// Additional
+	// fields, log fields overwrite provider attributes.
+	for k, v := range log.Attr {
+		o.add(msg, k, v)
+	}
+	for k, v := range log.Fields {
+		o.add(msg, k, v)
+	}
+
+	o.add(msg, "level_name", strings.ToLower(log.Level.String()))
+	if !log.TraceId.IsZero() {
+		o.add(msg, "trace_id", log.TraceId.String())
+		o.add(msg, "span_id", log.SpanId.String())
+	}
+	if log.Caller != "" {
+		o.add(msg, "caller", log.Caller)
+	}
+
+	return json.Marshal(msg)
+}
+
+// Field
+// returns name of additional field, underscore prefixed, characters
+// other than letters, digits, underscore, dash and dot replaced with
+// underscore. Reserved _id renamed to _id_.
+//
+//	user id => _user_id
+func Field(key string) string {
+	key = "_" + strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, key)
+
+	if key == "_id" {
+		return "_id_"
+	}
+	return key
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Formatter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// add
+// additional field, value other than string and number converted to
+// string.
+func (o *formatter) add(msg map[string]interface{}, key string, value interface{}) {
+	switch v := value.(type) {
+	case string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+	case bool:
+		value = fmt.Sprintf("%v", v)
+	case error:
+		value = v.Error()
+	case fmt.Stringer:
+		value = v.String()
+	default:
+		if buf, err := json.Marshal(v); err == nil {
+			value = string(buf)
+		} else {
+			value = fmt.Sprintf("%v", v)
+		}
+	}
+	msg[Field(key)] = value
+}
+
+func (o *formatter) init(c config.GelfLoggerConfiguration) *formatter {
+	o.host = c.GetHost()
+	return o
+}
@@ -0,0 +1,186 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_gelf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	chunkHeaderSize = 12
+	chunkMaxCount   = 128
+)
+
+var (
+	chunkMagic = []byte{0x1e, 0x0f}
+)
+
+type (
+	// writer
+	// send messages on udp with chunking and compression, or on tcp
+	// delimited by null byte. Connection reopened on next write if
+	// error occurred.
+	writer struct {
+		sync.Mutex
+
+		address     string
+		chunkSize   int
+		compression string
+		conn        net.Conn
+		network     string
+		timeout     time.Duration
+	}
+)
+
+// Close
+// close connection.
+func (o *writer) Close() error {
+	o.Lock()
+	defer o.Unlock()
+
+	if o.conn != nil {
+		err := o.conn.Close()
+		o.conn = nil
+		return err
+	}
+	return nil
+}
+
+// Write
+// send a message.
+func (o *writer) Write(msg []byte) (err error) {
+	var packets [][]byte
+	if packets, err = o.packets(msg); err != nil {
+		return
+	}
+
+	o.Lock()
+	defer o.Unlock()
+
+	if o.conn == nil {
+		if o.conn, err = net.DialTimeout(o.network, o.address, o.timeout); err != nil {
+			return
+		}
+	}
+
+	if err = o.conn.SetWriteDeadline(time.Now().Add(o.timeout)); err == nil {
+		for _, p := range packets {
+			if _, err = o.conn.Write(p); err != nil {
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		_ = o.conn.Close()
+		o.conn = nil
+	}
+	return
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Writer: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// compress
+// message with gzip or zlib.
+func (o *writer) compress(msg []byte) ([]byte, error) {
+	var (
+		buf = &bytes.Buffer{}
+		w   io.WriteCloser
+	)
+
+	switch o.compression {
+	case "gzip":
+		w = gzip.NewWriter(buf)
+	case "zlib":
+		w = zlib.NewWriter(buf)
+	default:
+		return msg, nil
+	}
+
+	if _, err := w.Write(msg); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (o *writer) init(network, address string, chunkSize int, compression string, timeout time.Duration) *writer {
+	o.address = address
+	o.chunkSize = chunkSize
+	o.compression = compression
+	o.network = network
+	o.timeout = timeout
+	return o
+}
+
+// packets
+// returns datagrams or stream bytes of message.
+//
+// TCP: message followed by a null byte, not compressed.
+//
+// UDP: compressed message, split into chunks if larger than chunk
+// size. Each chunk starts with magic bytes 0x1e 0x0f, 8 bytes message
+// id, 1 byte sequence number and 1 byte sequence count.
+func (o *writer) packets(msg []byte) ([][]byte, error) {
+	if o.network != "udp" && o.network != "udp4" && o.network != "udp6" {
+		return [][]byte{append(msg, 0)}, nil
+	}
+
+	msg, err := o.compress(msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(msg) <= o.chunkSize {
+		return [][]byte{msg}, nil
+	}
+
+	size := o.chunkSize - chunkHeaderSize
+	count := (len(msg) + size - 1) / size
+	if count > chunkMaxCount {
+		return nil, fmt.Errorf("gelf: message of %d bytes exceeds %d chunks", len(msg), chunkMaxCount)
+	}
+
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+
+	list := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * size
+		if end > len(msg) {
+			end = len(msg)
+		}
+
+		p := make([]byte, 0, chunkHeaderSize+end-i*size)
+		p = append(p, chunkMagic...)
+		p = append(p, id...)
+		p = append(p, byte(i), byte(count))
+		p = append(p, msg[i*size:end]...)
+		list = append(list, p)
+	}
+	return list, nil
+}
@@ -0,0 +1,129 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_journald
+
+import (
+	"context"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"net"
+	"sync"
+)
+
+type (
+	Exporter interface {
+		Push(log *tracer.Log) error
+		Start(ctx context.Context) error
+		Stopped() bool
+	}
+
+	// Option
+	// custom exporter options.
+	Option func(o *exporter)
+
+	exporter struct {
+		sync.Mutex
+
+		conn      *net.UnixConn
+		formatter Formatter
+		socket    string
+	}
+)
+
+// New
+// returns a journald logger exporter, configured by journald-logger
+// section of log.yaml.
+func New(opts ...Option) Exporter {
+	o := (&exporter{}).init()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithFormatter
+// use specified formatter.
+func WithFormatter(f Formatter) Option {
+	return func(o *exporter) {
+		if f != nil {
+			o.formatter = f
+		}
+	}
+}
+
+// Push
+// send log as a datagram to journald socket, entry too large for a
+// datagram sent by file descriptor.
+func (o *exporter) Push(log *tracer.Log) (err error) {
+	buf := o.formatter.Format(log)
+
+	o.Lock()
+	defer o.Unlock()
+
+	if err = o.connect(); err != nil {
+		return
+	}
+
+	if _, err = o.conn.Write(buf); err == nil {
+		return
+	}
+
+	// Send
+	// large entry by file descriptor.
+	if tooLarge(err) {
+		return sendFd(o.conn, buf)
+	}
+
+	// Reconnect
+	// on next push.
+	_ = o.conn.Close()
+	o.conn = nil
+	return
+}
+
+func (o *exporter) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	o.Lock()
+	defer o.Unlock()
+
+	if o.conn != nil {
+		err := o.conn.Close()
+		o.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (o *exporter) Stopped() bool { return true }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Exporter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *exporter) connect() (err error) {
+	if o.conn == nil {
+		o.conn, err = net.DialUnix("unixgram", nil, &net.UnixAddr{Name: o.socket, Net: "unixgram"})
+	}
+	return
+}
+
+func (o *exporter) init() *exporter {
+	c := config.Config.GetJournaldLogger()
+	o.formatter = (&formatter{}).init(c)
+	o.socket = c.GetSocket()
+	return o
+}
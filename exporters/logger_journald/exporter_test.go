@@ -0,0 +1,169 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+//go:build linux
+// +build linux
+
+package logger_journald
+
+import (
+	"encoding/binary"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestExporter_Push(t *testing.T) {
+	conn := listen(t)
+
+	x := tracer.NewLog(tracer.LogSpan, config.Error)
+	x.Attr = tracer.Attr{"service.name": "journald-test"}
+	x.Text = "first line\nsecond line"
+	x.Caller = "dir/file.go:12"
+	x.TraceId = tracer.Identify.NewTraceId()
+	x.SpanId = tracer.Identify.NewSpanId()
+	x.Fields = tracer.Attr{"http.method": "GET", "_private": 1}
+
+	if err := New().Push(x); err != nil {
+		t.Fatalf("push error: %v", err)
+	}
+
+	fields := parse(t, read(t, conn))
+	for k, v := range map[string]string{
+		"MESSAGE":      x.Text,
+		"PRIORITY":     "3",
+		"TRACE_ID":     x.TraceId.String(),
+		"SPAN_ID":      x.SpanId.String(),
+		"CODE_FILE":    "dir/file.go",
+		"CODE_LINE":    "12",
+		"HTTP_METHOD":  "GET",
+		"PRIVATE":      "1",
+		"SERVICE_NAME": "journald-test",
+	} {
+		if fields[k] != v {
+			t.Errorf("field %s: expect %q, got %q", k, v, fields[k])
+		}
+	}
+}
+
+func TestExporter_PushLarge(t *testing.T) {
+	conn := listen(t)
+	_ = conn.SetReadBuffer(4096)
+
+	x := tracer.NewLog(tracer.LogSpan, config.Info)
+	x.Text = strings.Repeat("x", 4<<20)
+
+	if err := New().Push(x); err != nil {
+		t.Fatalf("push error: %v", err)
+	}
+
+	fields := parse(t, read(t, conn))
+	if fields["MESSAGE"] != x.Text {
+		t.Errorf("unexpected message of %d bytes", len(fields["MESSAGE"]))
+	}
+}
+
+func TestKey(t *testing.T) {
+	for s, k := range map[string]string{
+		"service.name": "SERVICE_NAME",
+		"_x-y":         "X_Y",
+		"9lives":       "LIVES",
+		"":             "FIELD",
+	} {
+		if v := Key(s); v != k {
+			t.Errorf("key of %q: expect %q, got %q", s, k, v)
+		}
+	}
+}
+
+// listen
+// a datagram socket stand-in for journald.
+func listen(t *testing.T) *net.UnixConn {
+	path := filepath.Join(t.TempDir(), "socket")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("listen error: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	config.Config.With(config.JournaldSocket(path))
+	return conn
+}
+
+// read
+// returns a datagram payload, or content of passed file descriptor.
+func read(t *testing.T, conn *net.UnixConn) []byte {
+	buf, oob := make([]byte, 1<<16), make([]byte, 64)
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+
+	n, on, _, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		t.Fatalf("read error: %v", err)
+	}
+	if on == 0 {
+		return buf[:n]
+	}
+
+	msgs, err := syscall.ParseSocketControlMessage(oob[:on])
+	if err != nil || len(msgs) != 1 {
+		t.Fatalf("parse control message error: %v", err)
+	}
+	fds, err := syscall.ParseUnixRights(&msgs[0])
+	if err != nil || len(fds) != 1 {
+		t.Fatalf("parse unix rights error: %v", err)
+	}
+
+	f := os.NewFile(uintptr(fds[0]), "journal")
+	defer func() { _ = f.Close() }()
+
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("seek error: %v", err)
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("read fd error: %v", err)
+	}
+	return data
+}
+
+// parse
+// decode native protocol entry.
+func parse(t *testing.T, buf []byte) map[string]string {
+	fields := make(map[string]string)
+	for len(buf) > 0 {
+		i := strings.IndexAny(string(buf), "=\n")
+		if i < 0 {
+			t.Fatalf("invalid entry: %q", buf)
+		}
+
+		key := string(buf[:i])
+		if buf[i] == '=' {
+			j := strings.IndexByte(string(buf[i:]), '\n') + i
+			fields[key], buf = string(buf[i+1:j]), buf[j+1:]
+			continue
+		}
+
+		size := int(binary.LittleEndian.Uint64(buf[i+1 : i+9]))
+		fields[key], buf = string(buf[i+9:i+9+size]), buf[i+10+size:]
+	}
+	return fields
+}
@@ -0,0 +1,85 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+//go:build linux
+// +build linux
+
+package logger_journald
+
+import (
+	"errors"
+	"golang.org/x/sys/unix"
+	"net"
+	"os"
+	"syscall"
+)
+
+const (
+	sealAll = unix.F_SEAL_SEAL | unix.F_SEAL_SHRINK | unix.F_SEAL_GROW | unix.F_SEAL_WRITE
+)
+
+// sendFd
+// write entry into a sealed memfd and send the descriptor to journald,
+// an unlinked file in /dev/shm used if memfd is not supported.
+func sendFd(conn *net.UnixConn, buf []byte) (err error) {
+	var f *os.File
+
+	if f, err = memfd(); err != nil {
+		if f, err = os.CreateTemp("/dev/shm", "journal.*"); err != nil {
+			return
+		}
+		_ = os.Remove(f.Name())
+	}
+	defer func() { _ = f.Close() }()
+
+	if _, err = f.Write(buf); err != nil {
+		return
+	}
+
+	// Seal
+	// memfd, ignored for regular file.
+	_, _ = unix.FcntlInt(f.Fd(), unix.F_ADD_SEALS, sealAll)
+
+	// Send
+	// descriptor on connected socket, net.UnixConn refuses
+	// WriteMsgUnix on connected datagram socket.
+	var raw syscall.RawConn
+	if raw, err = conn.SyscallConn(); err != nil {
+		return
+	}
+	if we := raw.Write(func(fd uintptr) bool {
+		err = unix.Sendmsg(int(fd), nil, unix.UnixRights(int(f.Fd())), nil, 0)
+		return err != unix.EAGAIN
+	}); we != nil {
+		err = we
+	}
+	return
+}
+
+// memfd
+// create an anonymous memory file.
+func memfd() (*os.File, error) {
+	fd, err := unix.MemfdCreate("journal", unix.MFD_CLOEXEC|unix.MFD_ALLOW_SEALING)
+	if err != nil {
+		return nil, err
+	}
+	return os.NewFile(uintptr(fd), "journal"), nil
+}
+
+// tooLarge
+// return true if entry exceeds datagram size.
+func tooLarge(err error) bool {
+	return errors.Is(err, unix.EMSGSIZE) || errors.Is(err, unix.ENOBUFS)
+}
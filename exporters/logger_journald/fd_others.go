@@ -0,0 +1,34 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+//go:build !linux
+// +build !linux
+
+package logger_journald
+
+import (
+	"errors"
+	"net"
+)
+
+// sendFd
+// journald runs on linux only.
+func sendFd(_ *net.UnixConn, _ []byte) error {
+	return errors.New("journald: file descriptor passing not supported")
+}
+
+// tooLarge
+// always false on platforms without journald.
+func tooLarge(_ error) bool { return false }
@@ -0,0 +1,135 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_journald
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/exporters/severity"
+	"github.com/fuyibing/log/tracer"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	Formatter interface {
+		Format(log *tracer.Log) []byte
+	}
+
+	formatter struct {
+		attributes []string
+		identifier string
+	}
+)
+
+// Format
+// serialize log into journald native protocol. Fields in form of
+// KEY=value, value with newline serialized as KEY, newline, 64-bit
+// little endian size, value and newline.
+func (o *formatter) Format(log *tracer.Log) []byte {
+	buf := &bytes.Buffer{}
+
+	o.write(buf, "MESSAGE", log.Text)
+	o.write(buf, "PRIORITY", strconv.Itoa(severity.Syslog(log.Level)))
+	o.write(buf, "LOG_LEVEL", log.Level.String())
+	o.write(buf, "SYSLOG_IDENTIFIER", o.identifier)
+
+	if !log.TraceId.IsZero() {
+		o.write(buf, "TRACE_ID", log.TraceId.String())
+		o.write(buf, "SPAN_ID", log.SpanId.String())
+	}
+
+	// Caller
+	// such as dir/file.go:12.
+	if i := strings.LastIndex(log.Caller, ":"); i > 0 {
+		o.write(buf, "CODE_FILE", log.Caller[:i])
+		o.write(buf, "CODE_LINE", log.Caller[i+1:])
+	}
+
+	// Log fields
+	// sorted by key.
+	keys := make([]string, 0, len(log.Fields))
+	for k := range log.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		o.write(buf, Key(k), fmt.Sprintf("%v", log.Fields[k]))
+	}
+
+	// Provider attributes
+	// of log in configured order.
+	for _, k := range o.attributes {
+		if v, ok := log.Attr[k]; ok {
+			o.write(buf, Key(k), fmt.Sprintf("%v", v))
+		}
+	}
+
+	return buf.Bytes()
+}
+
+// Key
+// returns a valid journald field name: upper case letters, digits and
+// underscores, not starts with underscore or digit, max 64 characters.
+//
+//	service.name => SERVICE_NAME
+func Key(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_':
+			return r
+		}
+		return '_'
+	}, s)
+
+	s = strings.TrimLeft(s, "_0123456789")
+	if s == "" {
+		s = "FIELD"
+	}
+	if len(s) > 64 {
+		s = s[:64]
+	}
+	return s
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Formatter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *formatter) init(c config.JournaldLoggerConfiguration) *formatter {
+	o.attributes = c.GetAttributes()
+	o.identifier = c.GetIdentifier()
+	return o
+}
+
+func (o *formatter) write(buf *bytes.Buffer, key, value string) {
+	buf.WriteString(key)
+
+	if strings.Contains(value, "\n") {
+		buf.WriteByte('\n')
+		_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	} else {
+		buf.WriteByte('=')
+	}
+
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
@@ -0,0 +1,366 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_kafka
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type (
+	// client
+	// a minimal kafka producer client, supports metadata and
+	// produce apis only.
+	client struct {
+		sync.Mutex
+
+		bootstrap   []string
+		clientId    string
+		correlation int32
+		timeout     time.Duration
+
+		addrs      map[int32]string
+		conns      map[int32]net.Conn
+		partitions map[string][]partition
+	}
+
+	partition struct {
+		Id, Leader int32
+	}
+)
+
+// Close
+// close all connections.
+func (o *client) Close() {
+	o.Lock()
+	defer o.Unlock()
+
+	for id, conn := range o.conns {
+		_ = conn.Close()
+		delete(o.conns, id)
+	}
+}
+
+// Partitions
+// returns partitions of topic, metadata loaded if not cached.
+func (o *client) Partitions(topic string) (list []partition, err error) {
+	o.Lock()
+	list = o.partitions[topic]
+	o.Unlock()
+
+	if len(list) > 0 {
+		return
+	}
+	if err = o.Refresh(topic); err != nil {
+		return
+	}
+
+	o.Lock()
+	list = o.partitions[topic]
+	o.Unlock()
+
+	if len(list) == 0 {
+		err = kafkaError(3)
+	}
+	return
+}
+
+// Produce
+// send record batches to the leader of partitions, returns error of
+// each failed partition.
+func (o *client) Produce(topic string, acks int16, batches map[int32][]byte) (failed map[int32]error) {
+	var (
+		groups  = make(map[int32][]int32)
+		leaders = make(map[int32]int32)
+	)
+
+	failed = make(map[int32]error)
+
+	// Group
+	// partitions by leader.
+	o.Lock()
+	for _, p := range o.partitions[topic] {
+		leaders[p.Id] = p.Leader
+	}
+	o.Unlock()
+
+	for id := range batches {
+		if leader, ok := leaders[id]; ok && leader >= 0 {
+			groups[leader] = append(groups[leader], id)
+		} else {
+			failed[id] = kafkaError(5)
+		}
+	}
+
+	for leader, ids := range groups {
+		body := &encoder{}
+		body.nullString()
+		body.int16(acks)
+		body.int32(int32(o.timeout.Milliseconds()))
+		body.int32(1)
+		body.string(topic)
+		body.int32(int32(len(ids)))
+		for _, id := range ids {
+			body.int32(id)
+			body.bytes(batches[id])
+		}
+
+		res, err := o.request(leader, apiProduce, versionProduce, body.Bytes(), acks != 0)
+		if err != nil {
+			for _, id := range ids {
+				failed[id] = err
+			}
+			continue
+		}
+		if acks == 0 {
+			continue
+		}
+
+		// Parse
+		// response of partitions.
+		dec := &decoder{buf: res}
+		for i, n := 0, dec.int32(); i < int(n) && dec.err == nil; i++ {
+			_ = dec.string()
+			for j, m := 0, dec.int32(); j < int(m) && dec.err == nil; j++ {
+				id := dec.int32()
+				code := dec.int16()
+				_ = dec.int64()
+				_ = dec.int64()
+				if code != 0 {
+					failed[id] = kafkaError(code)
+				}
+			}
+		}
+		if dec.err != nil {
+			for _, id := range ids {
+				failed[id] = dec.err
+			}
+		}
+	}
+	return
+}
+
+// Refresh
+// load metadata of topic from any broker.
+func (o *client) Refresh(topic string) (err error) {
+	body := &encoder{}
+	body.int32(1)
+	body.string(topic)
+
+	for _, addr := range o.candidates() {
+		var (
+			conn net.Conn
+			res  []byte
+		)
+
+		if conn, err = net.DialTimeout("tcp", addr, o.timeout); err != nil {
+			continue
+		}
+		res, err = o.roundTrip(conn, apiMetadata, versionMetadata, body.Bytes(), true)
+		_ = conn.Close()
+
+		if err == nil {
+			if err = o.parseMetadata(res); err == nil {
+				return
+			}
+		}
+	}
+
+	if err == nil {
+		err = fmt.Errorf("kafka: no broker available")
+	}
+	return
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Client: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// candidates
+// returns known broker addresses, bootstrap brokers first.
+func (o *client) candidates() []string {
+	o.Lock()
+	defer o.Unlock()
+
+	list := append([]string{}, o.bootstrap...)
+	for _, addr := range o.addrs {
+		list = append(list, addr)
+	}
+	return list
+}
+
+func (o *client) conn(id int32) (conn net.Conn, err error) {
+	o.Lock()
+	defer o.Unlock()
+
+	if conn = o.conns[id]; conn != nil {
+		return
+	}
+
+	addr, ok := o.addrs[id]
+	if !ok {
+		return nil, fmt.Errorf("kafka: broker %d not found", id)
+	}
+	if conn, err = net.DialTimeout("tcp", addr, o.timeout); err == nil {
+		o.conns[id] = conn
+	}
+	return
+}
+
+func (o *client) init(bootstrap []string, clientId string, timeout time.Duration) *client {
+	o.addrs = make(map[int32]string)
+	o.bootstrap = bootstrap
+	o.clientId = clientId
+	o.conns = make(map[int32]net.Conn)
+	o.partitions = make(map[string][]partition)
+	o.timeout = timeout
+	return o
+}
+
+func (o *client) parseMetadata(res []byte) error {
+	var (
+		addrs      = make(map[int32]string)
+		dec        = &decoder{buf: res}
+		partitions = make(map[string][]partition)
+	)
+
+	// Brokers.
+	for i, n := 0, dec.int32(); i < int(n) && dec.err == nil; i++ {
+		id := dec.int32()
+		host := dec.string()
+		port := dec.int32()
+		_ = dec.string()
+		addrs[id] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+
+	// Controller.
+	_ = dec.int32()
+
+	// Topics.
+	for i, n := 0, dec.int32(); i < int(n) && dec.err == nil; i++ {
+		code := dec.int16()
+		name := dec.string()
+		_ = dec.bool()
+
+		list := make([]partition, 0)
+		for j, m := 0, dec.int32(); j < int(m) && dec.err == nil; j++ {
+			_ = dec.int16()
+			p := partition{Id: dec.int32(), Leader: dec.int32()}
+			for k, r := 0, dec.int32(); k < int(r) && dec.err == nil; k++ {
+				_ = dec.int32()
+			}
+			for k, r := 0, dec.int32(); k < int(r) && dec.err == nil; k++ {
+				_ = dec.int32()
+			}
+			list = append(list, p)
+		}
+
+		if code == 0 {
+			partitions[name] = list
+		}
+	}
+
+	if dec.err != nil {
+		return dec.err
+	}
+
+	o.Lock()
+	defer o.Unlock()
+
+	// Close
+	// connections of moved brokers.
+	for id, conn := range o.conns {
+		if addrs[id] != o.addrs[id] {
+			_ = conn.Close()
+			delete(o.conns, id)
+		}
+	}
+
+	o.addrs = addrs
+	for name, list := range partitions {
+		o.partitions[name] = list
+	}
+	return nil
+}
+
+// request
+// send request to broker, connection discarded if error occurred.
+func (o *client) request(id int32, key, version int16, body []byte, response bool) (res []byte, err error) {
+	var conn net.Conn
+
+	if conn, err = o.conn(id); err != nil {
+		return
+	}
+
+	if res, err = o.roundTrip(conn, key, version, body, response); err != nil {
+		o.Lock()
+		if o.conns[id] == conn {
+			delete(o.conns, id)
+		}
+		o.Unlock()
+		_ = conn.Close()
+	}
+	return
+}
+
+// roundTrip
+// write a request and read response on connection.
+func (o *client) roundTrip(conn net.Conn, key, version int16, body []byte, response bool) (res []byte, err error) {
+	o.Lock()
+	o.correlation++
+	correlation := o.correlation
+	o.Unlock()
+
+	req := &encoder{}
+	req.int32(0)
+	req.int16(key)
+	req.int16(version)
+	req.int32(correlation)
+	req.string(o.clientId)
+	req.raw(body)
+	binary.BigEndian.PutUint32(req.buf[0:4], uint32(len(req.buf)-4))
+
+	if err = conn.SetDeadline(time.Now().Add(o.timeout)); err != nil {
+		return
+	}
+	if _, err = conn.Write(req.Bytes()); err != nil || !response {
+		return
+	}
+
+	// Response
+	// header with size and correlation id.
+	head := make([]byte, 8)
+	if _, err = io.ReadFull(conn, head); err != nil {
+		return
+	}
+	if c := int32(binary.BigEndian.Uint32(head[4:8])); c != correlation {
+		return nil, fmt.Errorf("kafka: correlation id mismatch, expect %d, got %d", correlation, c)
+	}
+
+	size := binary.BigEndian.Uint32(head[0:4])
+	if size < 4 {
+		return nil, fmt.Errorf("kafka: invalid response size %d", size)
+	}
+
+	res = make([]byte, size-4)
+	_, err = io.ReadFull(conn, res)
+	return
+}
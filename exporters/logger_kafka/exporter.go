@@ -0,0 +1,210 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_kafka
+
+import (
+	"context"
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/exporters/batch"
+	"github.com/fuyibing/log/tracer"
+	"time"
+)
+
+type (
+	Exporter interface {
+		Push(log *tracer.Log) error
+		Start(ctx context.Context) error
+		Stopped() bool
+	}
+
+	// Option
+	// custom exporter options.
+	Option func(o *exporter)
+
+	exporter struct {
+		acks         int16
+		batcher      batch.Batcher
+		client       *client
+		compression  int8
+		formatter    Formatter
+		retries      int
+		retryBackoff time.Duration
+		topic        string
+
+		// err
+		// configuration error, returned by push and start.
+		err error
+
+		// roundRobin
+		// partition of logs without trace id, used by sender in
+		// sequence.
+		roundRobin int
+	}
+)
+
+// New
+// returns a kafka logger exporter, configured by kafka-logger section
+// of log.yaml.
+func New(opts ...Option) Exporter {
+	o := (&exporter{}).init()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithFormatter
+// use specified formatter.
+func WithFormatter(f Formatter) Option {
+	return func(o *exporter) {
+		if f != nil {
+			o.formatter = f
+		}
+	}
+}
+
+// Push
+// log into batch buffer, buffer sent immediately if exporter is not
+// started.
+func (o *exporter) Push(log *tracer.Log) error {
+	if o.err != nil {
+		return o.err
+	}
+	return o.batcher.Push(log)
+}
+
+func (o *exporter) Start(ctx context.Context) error {
+	if o.err != nil {
+		o.client.Close()
+		return o.err
+	}
+
+	err := o.batcher.Start(ctx)
+	o.client.Close()
+	return err
+}
+
+func (o *exporter) Stopped() bool { return o.batcher.Stopped() }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Exporter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *exporter) init() *exporter {
+	c := config.Config.GetKafkaLogger()
+
+	o.acks = int16(c.GetAcks())
+	o.batcher = batch.New("kafka logger", c.GetBatchSize(), time.Duration(c.GetBatchTimeout())*time.Millisecond, o.send)
+	o.client = (&client{}).init(c.GetBrokers(), c.GetClientId(), time.Duration(c.GetTimeout())*time.Millisecond)
+	o.formatter = (&formatter{}).init()
+	o.retries = c.GetRetries()
+	o.retryBackoff = time.Duration(c.GetRetryBackoff()) * time.Millisecond
+	o.topic = c.GetTopic()
+
+	// Compression
+	// codec not implemented is rejected, records are never sent
+	// uncompressed silently.
+	switch c.GetCompression() {
+	case "none":
+	case "gzip":
+		o.compression = compressionGzip
+	default:
+		o.err = fmt.Errorf("kafka logger: unsupported compression: %s", c.GetCompression())
+	}
+	return o
+}
+
+// partition
+// returns partition index of message, messages with same key (trace id)
+// assigned to same partition.
+func (o *exporter) partition(m *message, n int) int {
+	if m.Key != nil {
+		return int(murmur2(m.Key)&0x7fffffff) % n
+	}
+	o.roundRobin = (o.roundRobin + 1) % n
+	return o.roundRobin
+}
+
+// send
+// produce logs, retry failed partitions with retriable errors.
+func (o *exporter) send(logs []*tracer.Log) (err error) {
+	var (
+		list       []partition
+		messages   = make([]*message, 0, len(logs))
+		assignment map[int32][]*message
+	)
+
+	for _, log := range logs {
+		m := &message{Time: log.Time}
+		if m.Value, err = o.formatter.Format(log); err != nil {
+			return
+		}
+		if !log.TraceId.IsZero() {
+			m.Key = []byte(log.TraceId.String())
+		}
+		messages = append(messages, m)
+	}
+
+	for attempt := 0; attempt <= o.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(o.retryBackoff)
+			if err = o.client.Refresh(o.topic); err != nil {
+				continue
+			}
+		}
+
+		if list, err = o.client.Partitions(o.topic); err != nil {
+			continue
+		}
+
+		// Assign
+		// messages to partitions.
+		if assignment == nil {
+			assignment = make(map[int32][]*message)
+			for _, m := range messages {
+				p := list[o.partition(m, len(list))]
+				assignment[p.Id] = append(assignment[p.Id], m)
+			}
+		}
+
+		batches := make(map[int32][]byte)
+		for id, ms := range assignment {
+			if batches[id], err = encodeRecordBatch(ms, o.compression); err != nil {
+				return
+			}
+		}
+
+		// Remove
+		// sent partitions, retry failed partitions.
+		failed := o.client.Produce(o.topic, o.acks, batches)
+		for id := range assignment {
+			if fe, ok := failed[id]; ok {
+				if ke, is := fe.(kafkaError); is && !ke.Retriable() {
+					return fe
+				}
+				err = fe
+				continue
+			}
+			delete(assignment, id)
+		}
+
+		if len(assignment) == 0 {
+			return nil
+		}
+	}
+	return
+}
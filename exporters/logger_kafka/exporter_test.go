@@ -0,0 +1,298 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_kafka
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"hash/crc32"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBroker
+// a single node kafka broker supports metadata v1 and produce v3.
+type fakeBroker struct {
+	sync.Mutex
+	listener net.Listener
+	failures int
+	records  map[int32][][]byte
+	keys     map[int32][]string
+}
+
+func newFakeBroker(t *testing.T, failures int) *fakeBroker {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen error: %v", err)
+	}
+
+	b := &fakeBroker{listener: l, failures: failures, records: map[int32][][]byte{}, keys: map[int32][]string{}}
+	go func() {
+		for {
+			conn, ae := l.Accept()
+			if ae != nil {
+				return
+			}
+			go b.serve(t, conn)
+		}
+	}()
+	return b
+}
+
+func (o *fakeBroker) serve(t *testing.T, conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	for {
+		head := make([]byte, 4)
+		if _, err := io.ReadFull(conn, head); err != nil {
+			return
+		}
+		buf := make([]byte, binary.BigEndian.Uint32(head))
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			return
+		}
+
+		dec := &decoder{buf: buf}
+		key, _, correlation, _ := dec.int16(), dec.int16(), dec.int32(), dec.string()
+
+		res := &encoder{}
+		res.int32(0)
+		res.int32(correlation)
+
+		switch key {
+		case apiMetadata:
+			host, port, _ := net.SplitHostPort(o.listener.Addr().String())
+			p, _ := strconv.Atoi(port)
+			res.int32(1)
+			res.int32(0)
+			res.string(host)
+			res.int32(int32(p))
+			res.nullString()
+			res.int32(0)
+			res.int32(1)
+			res.int16(0)
+			res.string(dec.arrayFirstString())
+			res.int8(0)
+			res.int32(2)
+			for i := int32(0); i < 2; i++ {
+				res.int16(0)
+				res.int32(i)
+				res.int32(0)
+				res.int32(1)
+				res.int32(0)
+				res.int32(1)
+				res.int32(0)
+			}
+
+		case apiProduce:
+			_, _, _ = dec.int16(), dec.int16(), dec.int32()
+			_, topic := dec.int32(), dec.string()
+			n := dec.int32()
+
+			res.int32(1)
+			res.string(topic)
+			res.int32(n)
+			for i := int32(0); i < n; i++ {
+				id := dec.int32()
+				batch := dec.next(int(dec.int32()))
+
+				code := int16(0)
+				o.Lock()
+				if o.failures > 0 {
+					o.failures--
+					code = 6
+				} else if err := o.decodeBatch(id, batch); err != nil {
+					t.Errorf("decode batch error: %v", err)
+					code = 2
+				}
+				o.Unlock()
+
+				res.int32(id)
+				res.int16(code)
+				res.int64(0)
+				res.int64(-1)
+			}
+			res.int32(0)
+		}
+
+		binary.BigEndian.PutUint32(res.buf[0:4], uint32(len(res.buf)-4))
+		if _, err := conn.Write(res.Bytes()); err != nil {
+			return
+		}
+	}
+}
+
+func (o *fakeBroker) decodeBatch(id int32, batch []byte) error {
+	dec := &decoder{buf: batch}
+	_, _, _, _ = dec.int64(), dec.int32(), dec.int32(), dec.next(1)
+	crc := uint32(dec.int32())
+	if crc32.Checksum(batch[dec.off:], crcTable) != crc {
+		return io.ErrUnexpectedEOF
+	}
+
+	attributes := dec.int16()
+	_, _, _, _, _, _ = dec.int32(), dec.int64(), dec.int64(), dec.int64(), dec.int16(), dec.int32()
+	count := dec.int32()
+	records := batch[dec.off:]
+
+	if attributes&7 == int16(compressionGzip) {
+		zr, err := gzip.NewReader(bytes.NewReader(records))
+		if err != nil {
+			return err
+		}
+		if records, err = io.ReadAll(zr); err != nil {
+			return err
+		}
+	}
+
+	rd := bytes.NewReader(records)
+	for i := int32(0); i < count; i++ {
+		_, _ = binary.ReadVarint(rd)
+		_, _ = rd.ReadByte()
+		_, _ = binary.ReadVarint(rd)
+		_, _ = binary.ReadVarint(rd)
+		kl, _ := binary.ReadVarint(rd)
+		if kl < 0 {
+			kl = 0
+		}
+		k := make([]byte, kl)
+		_, _ = io.ReadFull(rd, k)
+		vl, _ := binary.ReadVarint(rd)
+		v := make([]byte, vl)
+		_, _ = io.ReadFull(rd, v)
+		_, _ = binary.ReadVarint(rd)
+
+		o.keys[id] = append(o.keys[id], string(k))
+		o.records[id] = append(o.records[id], v)
+	}
+	return nil
+}
+
+// arrayFirstString
+// returns the first element of a string array.
+func (o *decoder) arrayFirstString() string {
+	_ = o.int32()
+	return o.string()
+}
+
+func TestExporter_Produce(t *testing.T) {
+	broker := newFakeBroker(t, 1)
+	defer func() { _ = broker.listener.Close() }()
+
+	config.Config.With(config.KafkaBrokers(broker.listener.Addr().String()), config.KafkaTopic("log-test"))
+	ex := New().(*exporter)
+	ex.compression = compressionGzip
+	ex.retries = 2
+	ex.retryBackoff = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- ex.Start(ctx) }()
+
+	tr := tracer.Provider.NewTrace("kafka")
+	for i := 0; i < 10; i++ {
+		x := tracer.NewLog(tracer.LogSpan, config.Info)
+		x.Text = "log " + strconv.Itoa(i)
+		x.TraceId = tr.GetTraceId()
+		_ = ex.Push(x)
+	}
+	_ = ex.Push(tracer.NewLog(tracer.LogInternal, config.Warn))
+
+	time.Sleep(time.Millisecond * 10)
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("flush error: %v", err)
+	}
+
+	broker.Lock()
+	defer broker.Unlock()
+
+	total := 0
+	for id, list := range broker.records {
+		total += len(list)
+		for i, v := range list {
+			m := &formatterMessage{}
+			if err := json.Unmarshal(v, m); err != nil {
+				t.Errorf("message error: %v", err)
+			}
+			if k := broker.keys[id][i]; k != m.TraceId {
+				t.Errorf("key %q and trace id %q mismatch", k, m.TraceId)
+			}
+		}
+	}
+	if total != 11 {
+		t.Errorf("expect 11 records, got %d", total)
+	}
+
+	// Same trace id
+	// in same partition.
+	partitions := 0
+	for _, keys := range broker.keys {
+		for _, k := range keys {
+			if k != "" {
+				partitions++
+				break
+			}
+		}
+	}
+	if partitions != 1 {
+		t.Errorf("records of trace spread over %d partitions", partitions)
+	}
+}
+
+func TestExporter_Compression(t *testing.T) {
+	defer config.Config.With(config.KafkaCompression("none"))
+
+	config.Config.With(config.KafkaCompression("GZIP"))
+	if ex := New().(*exporter); ex.err != nil || ex.compression != compressionGzip {
+		t.Errorf("expect gzip compression, got %d, %v", ex.compression, ex.err)
+	}
+
+	// Unsupported
+	// codec rejected on push and start.
+	config.Config.With(config.KafkaCompression("snappy"))
+	ex := New()
+	if err := ex.Push(tracer.NewLog(tracer.LogInternal, config.Info)); err == nil {
+		t.Errorf("expect push error of unsupported compression")
+	}
+	if err := ex.Start(context.Background()); err == nil {
+		t.Errorf("expect start error of unsupported compression")
+	}
+}
+
+func TestMurmur2(t *testing.T) {
+	// Same as kafka java client: Utils.murmur2.
+	for s, expect := range map[string]int32{
+		"21":                         -973932308,
+		"foobar":                     -790332482,
+		"a-little-bit-long-string":   -985981536,
+		"a-little-bit-longer-string": -1486304829,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": -58897971,
+	} {
+		if v := murmur2([]byte(s)); v != expect {
+			t.Errorf("murmur2(%q) expect %d, got %d", s, expect, v)
+		}
+	}
+}
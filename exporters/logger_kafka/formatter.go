@@ -0,0 +1,66 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_kafka
+
+import (
+	"encoding/json"
+	"github.com/fuyibing/log/tracer"
+	"time"
+)
+
+type (
+	Formatter interface {
+		Format(log *tracer.Log) ([]byte, error)
+	}
+
+	formatter struct {
+	}
+
+	formatterMessage struct {
+		Time    string      `json:"time"`
+		Level   string      `json:"level"`
+		Text    string      `json:"text"`
+		TraceId string      `json:"trace_id,omitempty"`
+		SpanId  string      `json:"span_id,omitempty"`
+		Attr    tracer.Attr `json:"attr,omitempty"`
+	}
+)
+
+// Format
+// generate log as json message, provider attributes included.
+func (o *formatter) Format(log *tracer.Log) ([]byte, error) {
+	m := &formatterMessage{
+		Time:  log.Time.Format(time.RFC3339Nano),
+		Level: log.Level.String(),
+		Text:  log.Text,
+	}
+
+	if !log.TraceId.IsZero() {
+		m.TraceId = log.TraceId.String()
+		m.SpanId = log.SpanId.String()
+	}
+
+	m.Attr = log.Attr
+	return json.Marshal(m)
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Formatter: constructor
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *formatter) init() *formatter {
+	return o
+}
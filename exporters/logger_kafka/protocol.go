@@ -0,0 +1,298 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_kafka
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"time"
+)
+
+const (
+	apiProduce  int16 = 0
+	apiMetadata int16 = 3
+
+	versionProduce  int16 = 3
+	versionMetadata int16 = 1
+
+	compressionNone int8 = 0
+	compressionGzip int8 = 1
+)
+
+var (
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+	// retriableErrors
+	// kafka error codes can be resolved by retry.
+	retriableErrors = map[int16]string{
+		3:  "UNKNOWN_TOPIC_OR_PARTITION",
+		5:  "LEADER_NOT_AVAILABLE",
+		6:  "NOT_LEADER_FOR_PARTITION",
+		7:  "REQUEST_TIMED_OUT",
+		13: "NETWORK_EXCEPTION",
+		19: "NOT_ENOUGH_REPLICAS",
+		20: "NOT_ENOUGH_REPLICAS_AFTER_APPEND",
+	}
+)
+
+type (
+	// decoder
+	// read kafka primitive types in big endian.
+	decoder struct {
+		buf []byte
+		err error
+		off int
+	}
+
+	// encoder
+	// write kafka primitive types in big endian.
+	encoder struct {
+		buf []byte
+	}
+
+	// message
+	// a kafka record.
+	message struct {
+		Key, Value []byte
+		Time       time.Time
+	}
+
+	// kafkaError
+	// error code returned by broker.
+	kafkaError int16
+)
+
+// /////////////////////////////////////////////////////////////////////////////
+// Error
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o kafkaError) Error() string {
+	if s, ok := retriableErrors[int16(o)]; ok {
+		return fmt.Sprintf("kafka error: %d %s", o, s)
+	}
+	return fmt.Sprintf("kafka error: %d", o)
+}
+
+func (o kafkaError) Retriable() bool {
+	_, ok := retriableErrors[int16(o)]
+	return ok
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Encoder
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *encoder) Bytes() []byte { return o.buf }
+
+func (o *encoder) int8(v int8)    { o.buf = append(o.buf, byte(v)) }
+func (o *encoder) int16(v int16)  { o.buf = binary.BigEndian.AppendUint16(o.buf, uint16(v)) }
+func (o *encoder) int32(v int32)  { o.buf = binary.BigEndian.AppendUint32(o.buf, uint32(v)) }
+func (o *encoder) int64(v int64)  { o.buf = binary.BigEndian.AppendUint64(o.buf, uint64(v)) }
+func (o *encoder) raw(v []byte)   { o.buf = append(o.buf, v...) }
+func (o *encoder) varint(v int64) { o.buf = binary.AppendVarint(o.buf, v) }
+
+func (o *encoder) bytes(v []byte) {
+	o.int32(int32(len(v)))
+	o.raw(v)
+}
+
+func (o *encoder) nullString() { o.int16(-1) }
+
+func (o *encoder) string(s string) {
+	o.int16(int16(len(s)))
+	o.raw([]byte(s))
+}
+
+func (o *encoder) varintBytes(v []byte) {
+	if v == nil {
+		o.varint(-1)
+		return
+	}
+	o.varint(int64(len(v)))
+	o.raw(v)
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Decoder
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *decoder) next(n int) []byte {
+	if o.err != nil {
+		return nil
+	}
+	if n < 0 || o.off+n > len(o.buf) {
+		o.err = fmt.Errorf("kafka: insufficient data to decode")
+		return nil
+	}
+	v := o.buf[o.off : o.off+n]
+	o.off += n
+	return v
+}
+
+func (o *decoder) bool() bool {
+	if v := o.next(1); v != nil {
+		return v[0] != 0
+	}
+	return false
+}
+
+func (o *decoder) int16() int16 {
+	if v := o.next(2); v != nil {
+		return int16(binary.BigEndian.Uint16(v))
+	}
+	return 0
+}
+
+func (o *decoder) int32() int32 {
+	if v := o.next(4); v != nil {
+		return int32(binary.BigEndian.Uint32(v))
+	}
+	return 0
+}
+
+func (o *decoder) int64() int64 {
+	if v := o.next(8); v != nil {
+		return int64(binary.BigEndian.Uint64(v))
+	}
+	return 0
+}
+
+func (o *decoder) string() string {
+	n := o.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(o.next(int(n)))
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Record batch
+// /////////////////////////////////////////////////////////////////////////////
+
+// encodeRecordBatch
+// returns a record batch of magic v2.
+func encodeRecordBatch(list []*message, compression int8) (buf []byte, err error) {
+	var (
+		first = list[0].Time
+		last  = list[0].Time
+		recs  = &encoder{}
+	)
+
+	for _, m := range list {
+		if m.Time.Before(first) {
+			first = m.Time
+		}
+		if m.Time.After(last) {
+			last = m.Time
+		}
+	}
+
+	// Records.
+	for i, m := range list {
+		rec := &encoder{}
+		rec.int8(0)
+		rec.varint(m.Time.Sub(first).Milliseconds())
+		rec.varint(int64(i))
+		rec.varintBytes(m.Key)
+		rec.varintBytes(m.Value)
+		rec.varint(0)
+
+		recs.varint(int64(len(rec.buf)))
+		recs.raw(rec.buf)
+	}
+
+	// Compress
+	// records if required.
+	records := recs.Bytes()
+	if compression == compressionGzip {
+		zb := &bytes.Buffer{}
+		zw := gzip.NewWriter(zb)
+		if _, err = zw.Write(records); err == nil {
+			err = zw.Close()
+		}
+		if err != nil {
+			return
+		}
+		records = zb.Bytes()
+	}
+
+	// Part of crc, starts from attributes.
+	body := &encoder{}
+	body.int16(int16(compression))
+	body.int32(int32(len(list) - 1))
+	body.int64(first.UnixMilli())
+	body.int64(last.UnixMilli())
+	body.int64(-1)
+	body.int16(-1)
+	body.int32(-1)
+	body.int32(int32(len(list)))
+	body.raw(records)
+
+	batch := &encoder{}
+	batch.int64(0)
+	batch.int32(int32(4 + 1 + 4 + len(body.buf)))
+	batch.int32(-1)
+	batch.int8(2)
+	batch.int32(int32(crc32.Checksum(body.buf, crcTable)))
+	batch.raw(body.buf)
+	return batch.Bytes(), nil
+}
+
+// murmur2
+// hash used by default partitioner of kafka java client, same key
+// assigned to same partition with other clients.
+func murmur2(data []byte) int32 {
+	const (
+		seed uint32 = 0x9747b28c
+		m    uint32 = 0x5bd1e995
+		r           = 24
+	)
+
+	var (
+		length = len(data)
+		h      = seed ^ uint32(length)
+	)
+
+	for i := 0; i+4 <= length; i += 4 {
+		k := binary.LittleEndian.Uint32(data[i : i+4])
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+	}
+
+	tail := length &^ 3
+	switch length % 4 {
+	case 3:
+		h ^= uint32(data[tail+2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(data[tail+1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(data[tail])
+		h *= m
+	}
+
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return int32(h)
+}
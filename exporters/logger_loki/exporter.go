@@ -0,0 +1,278 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_loki
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/exporters/batch"
+	"github.com/fuyibing/log/tracer"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	Exporter interface {
+		Push(log *tracer.Log) error
+		Start(ctx context.Context) error
+		Stopped() bool
+	}
+
+	// Option
+	// custom exporter options.
+	Option func(o *exporter)
+
+	exporter struct {
+		batcher      batch.Batcher
+		client       *http.Client
+		compress     bool
+		formatter    Formatter
+		password     string
+		retries      int
+		retryBackoff time.Duration
+		tenantId     string
+		url          string
+		username     string
+	}
+
+	// pushRequest
+	// json body of loki push api.
+	pushRequest struct {
+		Streams []*pushStream `json:"streams"`
+	}
+
+	pushStream struct {
+		Stream map[string]string `json:"stream"`
+		Values [][]interface{}   `json:"values"`
+	}
+
+	// statusError
+	// returned if loki responds with non 2xx status.
+	statusError struct {
+		code       int
+		body       string
+		retryAfter time.Duration
+	}
+)
+
+// New
+// returns a loki logger exporter, configured by loki-logger section
+// of log.yaml.
+func New(opts ...Option) Exporter {
+	o := (&exporter{}).init()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithClient
+// use specified http client.
+func WithClient(c *http.Client) Option {
+	return func(o *exporter) {
+		if c != nil {
+			o.client = c
+		}
+	}
+}
+
+// WithFormatter
+// use specified formatter.
+func WithFormatter(f Formatter) Option {
+	return func(o *exporter) {
+		if f != nil {
+			o.formatter = f
+		}
+	}
+}
+
+// Push
+// log into batch buffer, buffer sent immediately if exporter is not
+// started.
+func (o *exporter) Push(log *tracer.Log) error { return o.batcher.Push(log) }
+
+func (o *exporter) Start(ctx context.Context) error { return o.batcher.Start(ctx) }
+
+func (o *exporter) Stopped() bool { return o.batcher.Stopped() }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Exporter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// encode
+// group logs into streams by labels, returns request body.
+func (o *exporter) encode(logs []*tracer.Log) (body []byte, err error) {
+	var (
+		req     = &pushRequest{}
+		streams = make(map[string]*pushStream)
+	)
+
+	for _, log := range logs {
+		entry := o.formatter.Format(log)
+		key := o.streamKey(entry.Labels)
+
+		s, ok := streams[key]
+		if !ok {
+			s = &pushStream{Stream: entry.Labels}
+			streams[key] = s
+			req.Streams = append(req.Streams, s)
+		}
+
+		value := []interface{}{strconv.FormatInt(log.Time.UnixNano(), 10), entry.Line}
+		if len(entry.Metadata) > 0 {
+			value = append(value, entry.Metadata)
+		}
+		s.Values = append(s.Values, value)
+	}
+
+	if body, err = json.Marshal(req); err != nil || !o.compress {
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err = w.Write(body); err == nil {
+		err = w.Close()
+	}
+	return buf.Bytes(), err
+}
+
+func (o *exporter) init() *exporter {
+	c := config.Config.GetLokiLogger()
+
+	o.batcher = batch.New("loki logger", c.GetBatchSize(), time.Duration(c.GetBatchTimeout())*time.Millisecond, o.send)
+	o.client = &http.Client{Timeout: time.Duration(c.GetTimeout()) * time.Millisecond}
+	o.compress = c.GetCompression() == "gzip"
+	o.formatter = (&formatter{}).init(c)
+	o.password = c.GetPassword()
+	o.retries = c.GetRetries()
+	o.retryBackoff = time.Duration(c.GetRetryBackoff()) * time.Millisecond
+	o.tenantId = c.GetTenantId()
+	o.url = c.GetUrl()
+	o.username = c.GetUsername()
+	return o
+}
+
+// post
+// send request body to loki.
+func (o *exporter) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, o.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if o.compress {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	if o.tenantId != "" {
+		req.Header.Set("X-Scope-OrgID", o.tenantId)
+	}
+	if o.username != "" {
+		req.SetBasicAuth(o.username, o.password)
+	}
+
+	res, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode/100 == 2 {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return nil
+	}
+
+	se := &statusError{code: res.StatusCode}
+	if buf, re := io.ReadAll(io.LimitReader(res.Body, 1024)); re == nil {
+		se.body = strings.TrimSpace(string(buf))
+	}
+	if n, pe := strconv.Atoi(res.Header.Get("Retry-After")); pe == nil && n > 0 {
+		se.retryAfter = time.Duration(n) * time.Second
+	}
+	return se
+}
+
+// send
+// push logs, retry on network error, 429 and 5xx responses with
+// exponential backoff.
+func (o *exporter) send(logs []*tracer.Log) (err error) {
+	var body []byte
+	if body, err = o.encode(logs); err != nil {
+		return
+	}
+
+	backoff := o.retryBackoff
+	for attempt := 0; attempt <= o.retries; attempt++ {
+		if attempt > 0 {
+			wait := backoff
+			if se, ok := err.(*statusError); ok && se.retryAfter > wait {
+				wait = se.retryAfter
+			}
+			time.Sleep(wait)
+			backoff *= 2
+		}
+
+		if err = o.post(body); err == nil {
+			return
+		}
+		if se, ok := err.(*statusError); ok && !se.Retriable() {
+			return
+		}
+	}
+	return
+}
+
+// streamKey
+// returns a key of labels, used to group logs into streams.
+func (o *exporter) streamKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	buf := &strings.Builder{}
+	for _, k := range keys {
+		buf.WriteString(k)
+		buf.WriteByte('=')
+		buf.WriteString(strconv.Quote(labels[k]))
+		buf.WriteByte(',')
+	}
+	return buf.String()
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Status error
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *statusError) Error() string {
+	return fmt.Sprintf("status %d: %s", o.code, o.body)
+}
+
+// Retriable
+// return true if status is 429 or 5xx.
+func (o *statusError) Retriable() bool {
+	return o.code == http.StatusTooManyRequests || o.code >= 500
+}
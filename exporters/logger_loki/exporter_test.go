@@ -0,0 +1,201 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_loki
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExporter_Push(t *testing.T) {
+	var received pushRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/loki/api/v1/push" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("X-Scope-OrgID") != "tenant" {
+			t.Errorf("unexpected tenant: %s", r.Header.Get("X-Scope-OrgID"))
+		}
+		if u, p, ok := r.BasicAuth(); !ok || u != "user" || p != "pass" {
+			t.Errorf("unexpected basic auth: %s %s", u, p)
+		}
+
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Fatalf("gzip error: %v", err)
+			}
+			body = gr
+		}
+		if err := json.NewDecoder(body).Decode(&received); err != nil {
+			t.Errorf("decode error: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	config.Config.With(config.LokiUrl(server.URL+"/loki/api/v1/push"), config.LokiLabels("service.name", "level"))
+	attr := tracer.Attr{"service.name": "loki-test"}
+
+	o := New().(*exporter)
+	o.compress = true
+	o.password = "pass"
+	o.tenantId = "tenant"
+	o.username = "user"
+
+	x1 := tracer.NewLog(tracer.LogSpan, config.Info)
+	x1.Text = "user login"
+	x1.TraceId = tracer.Identify.NewTraceId()
+	x1.SpanId = tracer.Identify.NewSpanId()
+	x1.Fields = tracer.Attr{"user": 1}
+	x1.Attr = attr
+
+	x2 := tracer.NewLog(tracer.LogSpan, config.Error)
+	x2.Text = "failed"
+	x2.Attr = attr
+
+	x3 := tracer.NewLog(tracer.LogSpan, config.Info)
+	x3.Text = "logout"
+	x3.Attr = attr
+
+	if err := o.send([]*tracer.Log{x1, x2, x3}); err != nil {
+		t.Fatalf("send error: %v", err)
+	}
+
+	if n := len(received.Streams); n != 2 {
+		t.Fatalf("expect 2 streams, got %d", n)
+	}
+
+	s := received.Streams[0]
+	if s.Stream["service_name"] != "loki-test" || s.Stream["level"] != "info" {
+		t.Errorf("unexpected labels: %v", s.Stream)
+	}
+	if len(s.Values) != 2 {
+		t.Fatalf("expect 2 values, got %d", len(s.Values))
+	}
+
+	line := s.Values[0][1].(string)
+	for _, str := range []string{`msg="user login"`, "user=1", "trace_id=" + x1.TraceId.String()} {
+		if !strings.Contains(line, str) {
+			t.Errorf("expect %s in line: %s", str, line)
+		}
+	}
+	if ts := s.Values[0][0].(string); ts == "" || strings.Contains(ts, ".") {
+		t.Errorf("unexpected timestamp: %s", ts)
+	}
+}
+
+func TestExporter_StructuredMetadata(t *testing.T) {
+	x := tracer.NewLog(tracer.LogSpan, config.Info)
+	x.Text = "user login"
+	x.TraceId = tracer.Identify.NewTraceId()
+	x.SpanId = tracer.Identify.NewSpanId()
+
+	e := (&formatter{metadata: true}).Format(x)
+	if e.Metadata["trace_id"] != x.TraceId.String() || e.Metadata["span_id"] != x.SpanId.String() {
+		t.Errorf("unexpected metadata: %v", e.Metadata)
+	}
+	if strings.Contains(e.Line, "trace_id") {
+		t.Errorf("unexpected trace id in line: %s", e.Line)
+	}
+}
+
+func TestFormatter_Labels(t *testing.T) {
+	x := tracer.NewLog(tracer.LogInternal, config.Info)
+	x.Attr = tracer.Attr{"loki.empty": ""}
+
+	f := &formatter{labels: []string{"not.exists", "loki.empty"}}
+	e := f.Format(x)
+	if len(e.Labels) != 1 || e.Labels["service_name"] == "" || e.Labels["service_name"] != f.serviceName() {
+		t.Errorf("expect service name label only, got %v", e.Labels)
+	}
+}
+
+func TestExporter_Retry(t *testing.T) {
+	var count int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&count, 1) {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	config.Config.With(config.LokiUrl(server.URL))
+
+	o := New().(*exporter)
+	o.retries = 3
+	o.retryBackoff = time.Millisecond
+
+	if err := o.send([]*tracer.Log{tracer.NewLog(tracer.LogSpan, config.Info)}); err != nil {
+		t.Fatalf("send error: %v", err)
+	}
+	if n := atomic.LoadInt32(&count); n != 3 {
+		t.Errorf("expect 3 requests, got %d", n)
+	}
+}
+
+func TestExporter_NotRetriable(t *testing.T) {
+	var count int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		http.Error(w, "entry out of order", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	config.Config.With(config.LokiUrl(server.URL))
+
+	o := New().(*exporter)
+	o.retries = 3
+	o.retryBackoff = time.Millisecond
+
+	err := o.send([]*tracer.Log{tracer.NewLog(tracer.LogSpan, config.Info)})
+	if err == nil || !strings.Contains(err.Error(), "entry out of order") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&count); n != 1 {
+		t.Errorf("expect 1 request, got %d", n)
+	}
+}
+
+func TestLabel(t *testing.T) {
+	for s, l := range map[string]string{
+		"service.name": "service_name",
+		"runtime-host": "runtime_host",
+		"1st":          "_1st",
+	} {
+		if v := Label(s); v != l {
+			t.Errorf("label of %q: expect %q, got %q", s, l, v)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_loki
+
+import (
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/exporters/logfmt"
+	"github.com/fuyibing/log/tracer"
+	"sort"
+	"strings"
+)
+
+type (
+	Formatter interface {
+		Format(log *tracer.Log) *Entry
+	}
+
+	// Entry
+	// a log line of loki stream.
+	Entry struct {
+		Labels   map[string]string
+		Line     string
+		Metadata map[string]string
+	}
+
+	formatter struct {
+		labels   []string
+		metadata bool
+	}
+)
+
+// Format
+// generate stream labels and log line. Line is logfmt encoded message,
+// caller and fields, trace id and span id appended if structured
+// metadata disabled.
+//
+//	msg="user login" caller=main.go:12 user=1 trace_id=... span_id=...
+func (o *formatter) Format(log *tracer.Log) *Entry {
+	var (
+		buf   = &strings.Builder{}
+		entry = &Entry{Labels: make(map[string]string)}
+	)
+
+	// Labels
+	// from provider attributes of log, level is the level of log.
+	// Empty value skipped as loki rejects it.
+	for _, k := range o.labels {
+		if k == "level" {
+			entry.Labels[k] = strings.ToLower(log.Level.String())
+			continue
+		}
+		if v, ok := log.Attr[k]; ok {
+			if str := fmt.Sprintf("%v", v); str != "" {
+				entry.Labels[Label(k)] = str
+			}
+		}
+	}
+
+	// Service name
+	// used if no label found, stream without label is rejected.
+	if len(entry.Labels) == 0 {
+		entry.Labels["service_name"] = o.serviceName()
+	}
+
+	o.write(buf, "msg", log.Text)
+	if log.Caller != "" {
+		o.write(buf, "caller", log.Caller)
+	}
+
+	keys := make([]string, 0, len(log.Fields))
+	for k := range log.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		o.write(buf, logfmt.Key(k), log.Fields[k])
+	}
+
+	if !log.TraceId.IsZero() {
+		if o.metadata {
+			entry.Metadata = map[string]string{
+				"trace_id": log.TraceId.String(),
+				"span_id":  log.SpanId.String(),
+			}
+		} else {
+			o.write(buf, "trace_id", log.TraceId.String())
+			o.write(buf, "span_id", log.SpanId.String())
+		}
+	}
+
+	entry.Line = buf.String()
+	return entry
+}
+
+// Label
+// returns a valid label name, characters other than letters, digits
+// and underscore replaced with underscore.
+//
+//	service.name => service_name
+func Label(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, s)
+
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		s = "_" + s
+	}
+	return s
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Formatter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *formatter) init(c config.LokiLoggerConfiguration) *formatter {
+	o.labels = c.GetLabels()
+	o.metadata = c.GetStructuredMetadata()
+	return o
+}
+
+// serviceName
+// returns configured service name, unknown_service if not configured.
+func (o *formatter) serviceName() string {
+	if s := config.Config.GetServiceName(); s != "" {
+		return s
+	}
+	return "unknown_service"
+}
+
+func (o *formatter) write(buf *strings.Builder, key string, value interface{}) {
+	if buf.Len() > 0 {
+		buf.WriteByte(' ')
+	}
+	buf.WriteString(key)
+	buf.WriteByte('=')
+	buf.WriteString(logfmt.Value(value))
+}
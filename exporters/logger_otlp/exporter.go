@@ -0,0 +1,267 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/exporters/batch"
+	"github.com/fuyibing/log/tracer"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ScopeName
+	// name of instrumentation scope.
+	ScopeName = "github.com/fuyibing/log"
+)
+
+type (
+	Exporter interface {
+		Push(log *tracer.Log) error
+		Start(ctx context.Context) error
+		Stopped() bool
+	}
+
+	// Option
+	// custom exporter options.
+	Option func(o *exporter)
+
+	exporter struct {
+		batcher      batch.Batcher
+		client       *http.Client
+		compress     bool
+		encoding     config.OtlpEncoding
+		endpoint     string
+		formatter    Formatter
+		headers      map[string]string
+		retries      int
+		retryBackoff time.Duration
+	}
+
+	// statusError
+	// returned if collector responds with non 2xx status.
+	statusError struct {
+		code       int
+		body       string
+		retryAfter time.Duration
+	}
+)
+
+// New
+// returns an otlp logger exporter, configured by otlp-logger section
+// of log.yaml.
+func New(opts ...Option) Exporter {
+	o := (&exporter{}).init()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithClient
+// use specified http client.
+func WithClient(c *http.Client) Option {
+	return func(o *exporter) {
+		if c != nil {
+			o.client = c
+		}
+	}
+}
+
+// WithFormatter
+// use specified formatter.
+func WithFormatter(f Formatter) Option {
+	return func(o *exporter) {
+		if f != nil {
+			o.formatter = f
+		}
+	}
+}
+
+// Push
+// log into batch buffer, buffer sent immediately if exporter is not
+// started.
+func (o *exporter) Push(log *tracer.Log) error { return o.batcher.Push(log) }
+
+func (o *exporter) Start(ctx context.Context) error { return o.batcher.Start(ctx) }
+
+func (o *exporter) Stopped() bool { return o.batcher.Stopped() }
+
+// /////////////////////////////////////////////////////////////////////////////
+// Exporter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// encode
+// returns request body and content type. Provider attributes of logs
+// used as resource attributes, logs of a provider in a resource.
+func (o *exporter) encode(logs []*tracer.Log) (body []byte, contentType string, err error) {
+	var (
+		req    = &ExportLogsServiceRequest{}
+		scopes = make(map[uintptr]*ScopeLogs)
+	)
+
+	for _, log := range logs {
+		// Resource
+		// for each provider, attributes of provider are shared by
+		// its logs.
+		key := reflect.ValueOf(log.Attr).Pointer()
+		scope, ok := scopes[key]
+		if !ok {
+			scope = &ScopeLogs{Scope: &InstrumentationScope{Name: ScopeName}}
+			scopes[key] = scope
+			req.ResourceLogs = append(req.ResourceLogs, &ResourceLogs{
+				Resource:  &Resource{Attributes: NewKeyValues(log.Attr)},
+				ScopeLogs: []*ScopeLogs{scope},
+			})
+		}
+		scope.LogRecords = append(scope.LogRecords, o.formatter.Format(log))
+	}
+
+	if o.encoding == config.OtlpJson {
+		contentType = "application/json"
+		if body, err = json.Marshal(req); err != nil {
+			return
+		}
+	} else {
+		contentType = "application/x-protobuf"
+		body = req.Marshal()
+	}
+
+	if o.compress {
+		buf := &bytes.Buffer{}
+		w := gzip.NewWriter(buf)
+		if _, err = w.Write(body); err == nil {
+			err = w.Close()
+		}
+		body = buf.Bytes()
+	}
+	return
+}
+
+func (o *exporter) init() *exporter {
+	c := config.Config.GetOtlpLogger()
+
+	o.batcher = batch.New("otlp logger", c.GetBatchSize(), time.Duration(c.GetBatchTimeout())*time.Millisecond, o.send)
+	o.client = &http.Client{Timeout: time.Duration(c.GetTimeout()) * time.Millisecond}
+	o.compress = c.GetCompression() == "gzip"
+	o.encoding = c.GetEncoding()
+	o.endpoint = c.GetEndpoint()
+	o.formatter = (&formatter{}).init()
+	o.headers = c.GetHeaders()
+	o.retries = c.GetRetries()
+	o.retryBackoff = time.Duration(c.GetRetryBackoff()) * time.Millisecond
+	return o
+}
+
+// post
+// send request body to collector.
+func (o *exporter) post(body []byte, contentType string) error {
+	req, err := http.NewRequest(http.MethodPost, o.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	for k, v := range o.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if o.compress {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	res, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode/100 == 2 {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return nil
+	}
+
+	se := &statusError{code: res.StatusCode}
+	if buf, re := io.ReadAll(io.LimitReader(res.Body, 1024)); re == nil {
+		se.body = strings.TrimSpace(string(buf))
+	}
+	if n, pe := strconv.Atoi(res.Header.Get("Retry-After")); pe == nil && n > 0 {
+		se.retryAfter = time.Duration(n) * time.Second
+	}
+	return se
+}
+
+// send
+// export logs, retry on network error and retryable status with
+// exponential backoff.
+func (o *exporter) send(logs []*tracer.Log) (err error) {
+	var (
+		body        []byte
+		contentType string
+	)
+
+	if body, contentType, err = o.encode(logs); err != nil {
+		return
+	}
+
+	backoff := o.retryBackoff
+	for attempt := 0; attempt <= o.retries; attempt++ {
+		if attempt > 0 {
+			wait := backoff
+			if se, ok := err.(*statusError); ok && se.retryAfter > wait {
+				wait = se.retryAfter
+			}
+			time.Sleep(wait)
+			backoff *= 2
+		}
+
+		if err = o.post(body, contentType); err == nil {
+			return
+		}
+		if se, ok := err.(*statusError); ok && !se.Retriable() {
+			return
+		}
+	}
+	return
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Status error
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *statusError) Error() string {
+	return fmt.Sprintf("status %d: %s", o.code, o.body)
+}
+
+// Retriable
+// return true if status is retryable by otlp specification: 429, 502,
+// 503 and 504.
+func (o *statusError) Retriable() bool {
+	switch o.code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
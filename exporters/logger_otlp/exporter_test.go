@@ -0,0 +1,235 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_otlp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExporter_Json(t *testing.T) {
+	var body map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected content type: %s", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode error: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	config.Config.With(config.OtlpEndpoint(server.URL), config.OtlpEncodingType(config.OtlpJson))
+
+	x := newLog()
+	x.Attr = tracer.Attr{"service.name": "otlp-test"}
+
+	// Other
+	// provider in another resource.
+	other := newLog()
+	other.Attr = tracer.Attr{"service.name": "otlp-other"}
+
+	if err := New().(*exporter).send([]*tracer.Log{x, other}); err != nil {
+		t.Fatalf("send error: %v", err)
+	}
+
+	list := body["resourceLogs"].([]interface{})
+	if len(list) != 2 {
+		t.Fatalf("expect 2 resources, got %d", len(list))
+	}
+	for i, name := range []string{"otlp-test", "otlp-other"} {
+		res := list[i].(map[string]interface{})["resource"].(map[string]interface{})
+		if v := attribute(res["attributes"], "service.name"); v["stringValue"] != name {
+			t.Errorf("unexpected resource attribute: %v", v)
+		}
+	}
+
+	rl := list[0].(map[string]interface{})
+
+	r := rl["scopeLogs"].([]interface{})[0].(map[string]interface{})["logRecords"].([]interface{})[0].(map[string]interface{})
+	for k, v := range map[string]interface{}{
+		"severityNumber": float64(13),
+		"severityText":   "WARN",
+		"traceId":        x.TraceId.String(),
+		"spanId":         x.SpanId.String(),
+		"timeUnixNano":   "1677222245123456789",
+	} {
+		if r[k] != v {
+			t.Errorf("record %s: expect %v, got %v", k, v, r[k])
+		}
+	}
+	if v := r["body"].(map[string]interface{}); v["stringValue"] != "disk almost full" {
+		t.Errorf("unexpected body: %v", v)
+	}
+	if v := attribute(r["attributes"], "count"); v["intValue"] != "3" {
+		t.Errorf("unexpected int attribute: %v", v)
+	}
+	if v := attribute(r["attributes"], "code.lineno"); v["intValue"] != "12" {
+		t.Errorf("unexpected code.lineno: %v", v)
+	}
+}
+
+func TestExporter_Protobuf(t *testing.T) {
+	var body []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/x-protobuf" {
+			t.Errorf("unexpected content type: %s", r.Header.Get("Content-Type"))
+		}
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	config.Config.With(config.OtlpEndpoint(server.URL), config.OtlpEncodingType(config.OtlpProtobuf))
+
+	x := newLog()
+	if err := New().(*exporter).send([]*tracer.Log{x}); err != nil {
+		t.Fatalf("send error: %v", err)
+	}
+
+	// Path
+	// resource_logs(1).scope_logs(2).log_records(2).
+	rl := fields(t, body)[1][0].([]byte)
+	sl := fields(t, rl)[2][0].([]byte)
+	if scope := fields(t, fields(t, sl)[1][0].([]byte)); string(scope[1][0].([]byte)) != ScopeName {
+		t.Errorf("unexpected scope: %s", scope[1][0])
+	}
+
+	r := fields(t, fields(t, sl)[2][0].([]byte))
+	if v := r[1][0].(uint64); v != uint64(x.Time.UnixNano()) {
+		t.Errorf("unexpected time: %d", v)
+	}
+	if v := r[2][0].(uint64); v != 13 {
+		t.Errorf("unexpected severity number: %d", v)
+	}
+	if v := string(r[3][0].([]byte)); v != "WARN" {
+		t.Errorf("unexpected severity text: %s", v)
+	}
+	if v := string(fields(t, r[5][0].([]byte))[1][0].([]byte)); v != x.Text {
+		t.Errorf("unexpected body: %s", v)
+	}
+	if len(r[6]) != 4 {
+		t.Errorf("expect 4 attributes, got %d", len(r[6]))
+	}
+	if v := r[9][0].([]byte); !bytes.Equal(v, x.TraceId.Byte()) {
+		t.Errorf("unexpected trace id: %x", v)
+	}
+	if v := r[10][0].([]byte); !bytes.Equal(v, x.SpanId.Byte()) {
+		t.Errorf("unexpected span id: %x", v)
+	}
+}
+
+func TestExporter_Retry(t *testing.T) {
+	var count int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&count, 1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	config.Config.With(config.OtlpEndpoint(server.URL))
+
+	o := New().(*exporter)
+	o.retries = 3
+	o.retryBackoff = time.Millisecond
+
+	if err := o.send([]*tracer.Log{newLog()}); err != nil {
+		t.Fatalf("send error: %v", err)
+	}
+	if err := o.send([]*tracer.Log{newLog()}); err == nil {
+		t.Errorf("expect error of bad request")
+	}
+	if n := atomic.LoadInt32(&count); n != 3 {
+		t.Errorf("expect 3 requests, got %d", n)
+	}
+}
+
+func TestSeverityNumber(t *testing.T) {
+	for level, n := range map[config.LoggerLevel]int32{
+		config.Fatal: 21, config.Error: 17, config.Warn: 13, config.Notice: 10,
+		config.Info: 9, config.Debug: 5, config.Trace: 1,
+	} {
+		if v := SeverityNumber(level); v != n {
+			t.Errorf("severity of %s: expect %d, got %d", level, n, v)
+		}
+	}
+}
+
+func newLog() *tracer.Log {
+	x := tracer.NewLog(tracer.LogSpan, config.Warn)
+	x.Text = "disk almost full"
+	x.Time = time.Unix(1677222245, 123456789)
+	x.Caller = "dir/main.go:12"
+	x.TraceId = tracer.Identify.NewTraceId()
+	x.SpanId = tracer.Identify.NewSpanId()
+	x.Fields = tracer.Attr{"count": 3, "usage": 0.95}
+	return x
+}
+
+// attribute
+// returns value of key in json key value list.
+func attribute(list interface{}, key string) map[string]interface{} {
+	for _, x := range list.([]interface{}) {
+		if kv := x.(map[string]interface{}); kv["key"] == key {
+			return kv["value"].(map[string]interface{})
+		}
+	}
+	return nil
+}
+
+// fields
+// decode protobuf message into values of field numbers, varint and
+// fixed64 as uint64, length delimited as bytes.
+func fields(t *testing.T, buf []byte) map[int][]interface{} {
+	m := map[int][]interface{}{}
+	for len(buf) > 0 {
+		tag, n := binary.Uvarint(buf)
+		buf = buf[n:]
+
+		field := int(tag >> 3)
+		switch tag & 7 {
+		case wireVarint:
+			v, vn := binary.Uvarint(buf)
+			m[field], buf = append(m[field], v), buf[vn:]
+		case wireFixed64:
+			m[field], buf = append(m[field], binary.LittleEndian.Uint64(buf)), buf[8:]
+		case wireBytes:
+			size, sn := binary.Uvarint(buf)
+			buf = buf[sn:]
+			m[field], buf = append(m[field], buf[:size]), buf[size:]
+		default:
+			t.Fatalf("unexpected wire type: %d", tag&7)
+		}
+	}
+	return m
+}
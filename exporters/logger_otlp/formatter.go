@@ -0,0 +1,100 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_otlp
+
+import (
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	Formatter interface {
+		Format(log *tracer.Log) *LogRecord
+	}
+
+	formatter struct {
+	}
+)
+
+// Format
+// convert log to opentelemetry log record. Caller converted to
+// code.filepath and code.lineno attributes.
+func (o *formatter) Format(log *tracer.Log) *LogRecord {
+	r := &LogRecord{
+		TimeUnixNano:         uint64(log.Time.UnixNano()),
+		ObservedTimeUnixNano: uint64(time.Now().UnixNano()),
+		SeverityNumber:       SeverityNumber(log.Level),
+		SeverityText:         log.Level.String(),
+		Body:                 NewAnyValue(log.Text),
+	}
+
+	attr := map[string]interface{}{}
+	for k, v := range log.Fields {
+		attr[k] = v
+	}
+	if i := strings.LastIndex(log.Caller, ":"); i > 0 {
+		attr["code.filepath"] = log.Caller[:i]
+		if n, err := strconv.Atoi(log.Caller[i+1:]); err == nil {
+			attr["code.lineno"] = n
+		}
+	}
+	if len(attr) > 0 {
+		r.Attributes = NewKeyValues(attr)
+	}
+
+	if !log.TraceId.IsZero() {
+		r.TraceId = log.TraceId.Byte()
+		r.SpanId = log.SpanId.Byte()
+	}
+	return r
+}
+
+// SeverityNumber
+// returns severity number of opentelemetry logs data model, custom
+// level mapped by integer.
+//
+//	FATAL: 21, ERROR: 17, WARN: 13, NOTICE: 10 (INFO2), INFO: 9, DEBUG: 5, TRACE: 1
+func SeverityNumber(level config.LoggerLevel) int32 {
+	i := level.Int()
+	switch {
+	case i <= config.Off.Int():
+		return 0
+	case i <= config.Fatal.Int():
+		return 21
+	case i <= config.Error.Int():
+		return 17
+	case i <= config.Warn.Int():
+		return 13
+	case i <= config.Notice.Int():
+		return 10
+	case i <= config.Info.Int():
+		return 9
+	case i <= config.Debug.Int():
+		return 5
+	}
+	return 1
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Formatter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *formatter) init() *formatter {
+	return o
+}
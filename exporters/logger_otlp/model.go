@@ -0,0 +1,222 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_otlp
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Kind of AnyValue.
+const (
+	kindEmpty = iota
+	kindString
+	kindBool
+	kindInt
+	kindDouble
+	kindArray
+	kindKvlist
+	kindBytes
+)
+
+type (
+	// ExportLogsServiceRequest
+	// body of otlp/http logs request.
+	ExportLogsServiceRequest struct {
+		ResourceLogs []*ResourceLogs `json:"resourceLogs"`
+	}
+
+	ResourceLogs struct {
+		Resource  *Resource    `json:"resource"`
+		ScopeLogs []*ScopeLogs `json:"scopeLogs"`
+	}
+
+	Resource struct {
+		Attributes []*KeyValue `json:"attributes"`
+	}
+
+	ScopeLogs struct {
+		Scope      *InstrumentationScope `json:"scope"`
+		LogRecords []*LogRecord          `json:"logRecords"`
+	}
+
+	InstrumentationScope struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}
+
+	// LogRecord
+	// a log of opentelemetry logs data model.
+	LogRecord struct {
+		TimeUnixNano         uint64      `json:"timeUnixNano,string"`
+		ObservedTimeUnixNano uint64      `json:"observedTimeUnixNano,string"`
+		SeverityNumber       int32       `json:"severityNumber"`
+		SeverityText         string      `json:"severityText"`
+		Body                 *AnyValue   `json:"body"`
+		Attributes           []*KeyValue `json:"attributes,omitempty"`
+		TraceId              HexBytes    `json:"traceId,omitempty"`
+		SpanId               HexBytes    `json:"spanId,omitempty"`
+	}
+
+	KeyValue struct {
+		Key   string    `json:"key"`
+		Value *AnyValue `json:"value"`
+	}
+
+	// AnyValue
+	// a value of string, bool, int, double, array, key value list
+	// or bytes.
+	AnyValue struct {
+		kind  int
+		str   string
+		bool  bool
+		int   int64
+		float float64
+		list  []*AnyValue
+		kv    []*KeyValue
+		bytes []byte
+	}
+
+	// HexBytes
+	// trace id and span id, encoded as hex string in json.
+	HexBytes []byte
+)
+
+// NewAnyValue
+// convert go value. Integers, floats, strings, bool, bytes, slices
+// and maps converted to corresponding kind, others converted to string
+// or json string.
+func NewAnyValue(value interface{}) *AnyValue {
+	switch v := value.(type) {
+	case nil:
+		return &AnyValue{}
+	case string:
+		return &AnyValue{kind: kindString, str: v}
+	case bool:
+		return &AnyValue{kind: kindBool, bool: v}
+	case int:
+		return &AnyValue{kind: kindInt, int: int64(v)}
+	case int8:
+		return &AnyValue{kind: kindInt, int: int64(v)}
+	case int16:
+		return &AnyValue{kind: kindInt, int: int64(v)}
+	case int32:
+		return &AnyValue{kind: kindInt, int: int64(v)}
+	case int64:
+		return &AnyValue{kind: kindInt, int: v}
+	case uint:
+		return NewAnyValue(uint64(v))
+	case uint8:
+		return &AnyValue{kind: kindInt, int: int64(v)}
+	case uint16:
+		return &AnyValue{kind: kindInt, int: int64(v)}
+	case uint32:
+		return &AnyValue{kind: kindInt, int: int64(v)}
+	case uint64:
+		if v > math.MaxInt64 {
+			return &AnyValue{kind: kindString, str: strconv.FormatUint(v, 10)}
+		}
+		return &AnyValue{kind: kindInt, int: int64(v)}
+	case float32:
+		return &AnyValue{kind: kindDouble, float: float64(v)}
+	case float64:
+		return &AnyValue{kind: kindDouble, float: v}
+	case []byte:
+		return &AnyValue{kind: kindBytes, bytes: v}
+	case []string:
+		a := &AnyValue{kind: kindArray}
+		for _, x := range v {
+			a.list = append(a.list, NewAnyValue(x))
+		}
+		return a
+	case []interface{}:
+		a := &AnyValue{kind: kindArray}
+		for _, x := range v {
+			a.list = append(a.list, NewAnyValue(x))
+		}
+		return a
+	case map[string]interface{}:
+		return &AnyValue{kind: kindKvlist, kv: NewKeyValues(v)}
+	case time.Time:
+		return &AnyValue{kind: kindString, str: v.Format(time.RFC3339Nano)}
+	case error:
+		return &AnyValue{kind: kindString, str: v.Error()}
+	case fmt.Stringer:
+		return &AnyValue{kind: kindString, str: v.String()}
+	}
+
+	if buf, err := json.Marshal(value); err == nil {
+		return &AnyValue{kind: kindString, str: string(buf)}
+	}
+	return &AnyValue{kind: kindString, str: fmt.Sprintf("%v", value)}
+}
+
+// NewKeyValues
+// returns key values sorted by key.
+func NewKeyValues(m map[string]interface{}) []*KeyValue {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	list := make([]*KeyValue, 0, len(keys))
+	for _, k := range keys {
+		list = append(list, &KeyValue{Key: k, Value: NewAnyValue(m[k])})
+	}
+	return list
+}
+
+// MarshalJSON
+// encode as otlp/json, int value encoded as string.
+func (o *AnyValue) MarshalJSON() ([]byte, error) {
+	switch o.kind {
+	case kindString:
+		return json.Marshal(map[string]string{"stringValue": o.str})
+	case kindBool:
+		return json.Marshal(map[string]bool{"boolValue": o.bool})
+	case kindInt:
+		return json.Marshal(map[string]string{"intValue": strconv.FormatInt(o.int, 10)})
+	case kindDouble:
+		return json.Marshal(map[string]float64{"doubleValue": o.float})
+	case kindArray:
+		list := o.list
+		if list == nil {
+			list = []*AnyValue{}
+		}
+		return json.Marshal(map[string]interface{}{"arrayValue": map[string]interface{}{"values": list}})
+	case kindKvlist:
+		kv := o.kv
+		if kv == nil {
+			kv = []*KeyValue{}
+		}
+		return json.Marshal(map[string]interface{}{"kvlistValue": map[string]interface{}{"values": kv}})
+	case kindBytes:
+		return json.Marshal(map[string][]byte{"bytesValue": o.bytes})
+	}
+	return []byte("{}"), nil
+}
+
+// MarshalJSON
+// encode as lower case hex string.
+func (o HexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToString(o))
+}
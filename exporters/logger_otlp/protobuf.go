@@ -0,0 +1,190 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_otlp
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+)
+
+type (
+	// protobuf
+	// a minimal protobuf writer of otlp messages.
+	protobuf struct {
+		buf []byte
+	}
+)
+
+// Marshal
+// encode request as protobuf.
+func (o *ExportLogsServiceRequest) Marshal() []byte {
+	p := &protobuf{}
+	for _, rl := range o.ResourceLogs {
+		p.message(1, rl.marshal)
+	}
+	return p.buf
+}
+
+func (o *ResourceLogs) marshal(p *protobuf) {
+	if o.Resource != nil {
+		p.message(1, o.Resource.marshal)
+	}
+	for _, sl := range o.ScopeLogs {
+		p.message(2, sl.marshal)
+	}
+}
+
+func (o *Resource) marshal(p *protobuf) {
+	for _, kv := range o.Attributes {
+		p.message(1, kv.marshal)
+	}
+}
+
+func (o *ScopeLogs) marshal(p *protobuf) {
+	if o.Scope != nil {
+		p.message(1, o.Scope.marshal)
+	}
+	for _, r := range o.LogRecords {
+		p.message(2, r.marshal)
+	}
+}
+
+func (o *InstrumentationScope) marshal(p *protobuf) {
+	p.string(1, o.Name)
+	p.string(2, o.Version)
+}
+
+func (o *LogRecord) marshal(p *protobuf) {
+	p.fixed64(1, o.TimeUnixNano)
+	p.varint(2, uint64(o.SeverityNumber))
+	p.string(3, o.SeverityText)
+	if o.Body != nil {
+		p.message(5, o.Body.marshal)
+	}
+	for _, kv := range o.Attributes {
+		p.message(6, kv.marshal)
+	}
+	p.bytes(9, o.TraceId)
+	p.bytes(10, o.SpanId)
+	p.fixed64(11, o.ObservedTimeUnixNano)
+}
+
+func (o *KeyValue) marshal(p *protobuf) {
+	p.string(1, o.Key)
+	if o.Value != nil {
+		p.message(2, o.Value.marshal)
+	}
+}
+
+// marshal
+// oneof field written even if it is zero value.
+func (o *AnyValue) marshal(p *protobuf) {
+	switch o.kind {
+	case kindString:
+		p.tag(1, wireBytes)
+		p.raw([]byte(o.str))
+	case kindBool:
+		p.tag(2, wireVarint)
+		if o.bool {
+			p.uvarint(1)
+		} else {
+			p.uvarint(0)
+		}
+	case kindInt:
+		p.tag(3, wireVarint)
+		p.uvarint(uint64(o.int))
+	case kindDouble:
+		p.tag(4, wireFixed64)
+		p.buf = binary.LittleEndian.AppendUint64(p.buf, math.Float64bits(o.float))
+	case kindArray:
+		p.message(5, func(x *protobuf) {
+			for _, v := range o.list {
+				x.message(1, v.marshal)
+			}
+		})
+	case kindKvlist:
+		p.message(6, func(x *protobuf) {
+			for _, kv := range o.kv {
+				x.message(1, kv.marshal)
+			}
+		})
+	case kindBytes:
+		p.tag(7, wireBytes)
+		p.raw(o.bytes)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Protobuf: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// bytes
+// length delimited field, omitted if empty.
+func (o *protobuf) bytes(field int, b []byte) {
+	if len(b) > 0 {
+		o.tag(field, wireBytes)
+		o.raw(b)
+	}
+}
+
+func (o *protobuf) fixed64(field int, v uint64) {
+	if v != 0 {
+		o.tag(field, wireFixed64)
+		o.buf = binary.LittleEndian.AppendUint64(o.buf, v)
+	}
+}
+
+// message
+// nested message, always written.
+func (o *protobuf) message(field int, fn func(p *protobuf)) {
+	x := &protobuf{}
+	fn(x)
+	o.tag(field, wireBytes)
+	o.raw(x.buf)
+}
+
+// raw
+// length prefixed bytes.
+func (o *protobuf) raw(b []byte) {
+	o.uvarint(uint64(len(b)))
+	o.buf = append(o.buf, b...)
+}
+
+func (o *protobuf) string(field int, s string) {
+	o.bytes(field, []byte(s))
+}
+
+func (o *protobuf) tag(field, wire int) {
+	o.uvarint(uint64(field<<3 | wire))
+}
+
+func (o *protobuf) uvarint(v uint64) {
+	o.buf = binary.AppendUvarint(o.buf, v)
+}
+
+func (o *protobuf) varint(field int, v uint64) {
+	if v != 0 {
+		o.tag(field, wireVarint)
+		o.uvarint(v)
+	}
+}
@@ -0,0 +1,149 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_syslog
+
+import (
+	"bufio"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExporter_Udp(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen error: %v", err)
+	}
+	defer func() { _ = pc.Close() }()
+
+	config.Config.With(config.SyslogAddress("udp", pc.LocalAddr().String()), config.SyslogMessageFormat(config.SyslogRfc5424))
+
+	x := tracer.NewLog(tracer.LogSpan, config.Warn)
+	x.Attr = tracer.Attr{"service.name": "syslog-test"}
+	x.Text = "disk almost full"
+	x.TraceId = tracer.Identify.NewTraceId()
+	x.SpanId = tracer.Identify.NewSpanId()
+	x.Fields = tracer.Attr{"path": `/var/"log"]`}
+
+	if err = New().Push(x); err != nil {
+		t.Fatalf("push error: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("read error: %v", err)
+	}
+
+	msg := string(buf[:n])
+	if !regexp.MustCompile(`^<12>1 \S+ \S+ \S+ \d+ - \[log@32473 `).MatchString(msg) {
+		t.Errorf("unexpected header: %s", msg)
+	}
+	for _, s := range []string{`trace_id="` + x.TraceId.String() + `"`, `path="/var/\"log\"\]"`, `service.name="syslog-test"`, "] disk almost full"} {
+		if !strings.Contains(msg, s) {
+			t.Errorf("expect %s in message: %s", s, msg)
+		}
+	}
+}
+
+func TestExporter_TcpOctetCounting(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen error: %v", err)
+	}
+	defer func() { _ = l.Close() }()
+
+	received := make(chan string, 2)
+	go func() {
+		conn, ae := l.Accept()
+		if ae != nil {
+			return
+		}
+		rd := bufio.NewReader(conn)
+		for {
+			s, re := rd.ReadString(' ')
+			if re != nil {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(s))
+			buf := make([]byte, n)
+			if _, re = io.ReadFull(rd, buf); re != nil {
+				return
+			}
+			received <- string(buf)
+		}
+	}()
+
+	config.Config.With(config.SyslogAddress("tcp", l.Addr().String()), config.SyslogMessageFormat(config.SyslogRfc3164))
+	ex := New()
+
+	for _, text := range []string{"first\nline", "second"} {
+		x := tracer.NewLog(tracer.LogInternal, config.Error)
+		x.Text = text
+		if err = ex.Push(x); err != nil {
+			t.Fatalf("push error: %v", err)
+		}
+	}
+
+	for _, expect := range []string{"first line", "second"} {
+		select {
+		case msg := <-received:
+			if !strings.HasPrefix(msg, "<11>") || !strings.HasSuffix(msg, "]: "+expect) {
+				t.Errorf("unexpected message: %q", msg)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("message not received")
+		}
+	}
+}
+
+func TestWriter_Reconnect(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen error: %v", err)
+	}
+	addr := l.Addr().String()
+	_ = l.Close()
+
+	config.Config.With(config.SyslogAddress("tcp", addr))
+	w, _ := (&writer{}).init(config.Config.GetSyslogLogger())
+	w.backoffMin = time.Millisecond * 20
+
+	if err = w.Write("lost"); err == nil {
+		t.Fatalf("expect dial error")
+	}
+	if err = w.Write("lost"); err == nil || !strings.Contains(err.Error(), "reconnect after") {
+		t.Fatalf("expect backoff error, got %v", err)
+	}
+
+	if l, err = net.Listen("tcp", addr); err != nil {
+		t.Skipf("listen again error: %v", err)
+	}
+	defer func() { _ = l.Close() }()
+
+	time.Sleep(w.backoffMin * 2)
+	if err = w.Write("ok"); err != nil {
+		t.Errorf("expect reconnected, got %v", err)
+	}
+	_ = w.Close()
+}
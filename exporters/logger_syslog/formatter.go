@@ -0,0 +1,168 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_syslog
+
+import (
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/exporters/severity"
+	"github.com/fuyibing/log/tracer"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	nilValue = "-"
+)
+
+var (
+	facilities = map[string]int{
+		"kern": 0, "user": 1, "mail": 2, "daemon": 3,
+		"auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+		"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+		"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+		"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+	}
+)
+
+type (
+	Formatter interface {
+		Format(log *tracer.Log) string
+	}
+
+	formatter struct {
+		appName  string
+		facility int
+		format   config.SyslogFormat
+		hostname string
+		pid      string
+		sdId     string
+	}
+)
+
+// Format
+// generate log as syslog message without framing.
+func (o *formatter) Format(log *tracer.Log) string {
+	pri := o.facility*8 + severity.Syslog(log.Level)
+
+	if o.format == config.SyslogRfc3164 {
+		// <PRI>TIMESTAMP HOSTNAME TAG[PID]: MSG
+		return fmt.Sprintf("<%d>%s %s %s[%s]: %s",
+			pri, log.Time.Format(time.Stamp), o.hostname, o.appName, o.pid,
+			strings.ReplaceAll(log.Text, "\n", " "),
+		)
+	}
+
+	// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID SD MSG
+	return fmt.Sprintf("<%d>1 %s %s %s %s %s %s %s",
+		pri, log.Time.Format("2006-01-02T15:04:05.000000Z07:00"),
+		o.hostname, o.appName, o.pid, nilValue,
+		o.structuredData(log), log.Text,
+	)
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Formatter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *formatter) init(c config.SyslogLoggerConfiguration) *formatter {
+	o.appName = o.header(config.Config.GetServiceName(), 48)
+	o.facility = facilities["user"]
+	o.format = c.GetFormat()
+	o.hostname = o.header(c.GetHostname(), 255)
+	o.pid = fmt.Sprintf("%d", os.Getpid())
+	o.sdId = o.name(c.GetSdId())
+
+	if f, ok := facilities[c.GetFacility()]; ok {
+		o.facility = f
+	}
+	return o
+}
+
+// header
+// returns a printable header field with max length, nil value used
+// if empty.
+func (o *formatter) header(s string, max int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, s)
+
+	if s == "" {
+		return nilValue
+	}
+	if len(s) > max {
+		s = s[:max]
+	}
+	return s
+}
+
+// name
+// returns a valid SD-NAME, max 32 printable ascii characters except
+// '=', ' ', ']' and '"'.
+func (o *formatter) name(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, s)
+
+	if len(s) > 32 {
+		s = s[:32]
+	}
+	return s
+}
+
+// structuredData
+// returns a structured data element with trace ids, log fields and
+// provider attributes.
+//
+//	[log@32473 trace_id="..." span_id="..." service.name="..."]
+func (o *formatter) structuredData(log *tracer.Log) string {
+	params := make([]string, 0)
+	add := func(k string, v interface{}) {
+		val := fmt.Sprintf("%v", v)
+		val = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(val)
+		params = append(params, fmt.Sprintf(`%s="%s"`, o.name(k), val))
+	}
+
+	add("level", log.Level.String())
+	if !log.TraceId.IsZero() {
+		add("trace_id", log.TraceId.String())
+		add("span_id", log.SpanId.String())
+	}
+	if log.Caller != "" {
+		add("caller", log.Caller)
+	}
+
+	for _, attr := range []tracer.Attr{log.Fields, log.Attr} {
+		keys := make([]string, 0, len(attr))
+		for k := range attr {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			add(k, attr[k])
+		}
+	}
+
+	return fmt.Sprintf("[%s %s]", o.sdId, strings.Join(params, " "))
+}
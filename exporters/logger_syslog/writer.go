@@ -0,0 +1,206 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_syslog
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	// localAddresses
+	// sockets of local syslog daemon.
+	localAddresses = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+)
+
+type (
+	// writer
+	// send messages on a connection, reconnect with exponential
+	// backoff if error occurred.
+	writer struct {
+		sync.Mutex
+
+		address string
+		conn    net.Conn
+		framing config.SyslogFraming
+		network string
+		timeout time.Duration
+		tls     *tls.Config
+
+		backoff, backoffMin, backoffMax time.Duration
+		nextDial                        time.Time
+	}
+)
+
+// Close
+// close connection.
+func (o *writer) Close() error {
+	o.Lock()
+	defer o.Unlock()
+
+	if o.conn != nil {
+		err := o.conn.Close()
+		o.conn = nil
+		return err
+	}
+	return nil
+}
+
+// Write
+// send a message, message discarded and an error returned if
+// connection is not available.
+func (o *writer) Write(msg string) (err error) {
+	o.Lock()
+	defer o.Unlock()
+
+	// Write
+	// on connection, retry once with new connection.
+	for i := 0; i < 2; i++ {
+		if err = o.connect(); err != nil {
+			return
+		}
+
+		if err = o.conn.SetWriteDeadline(time.Now().Add(o.timeout)); err == nil {
+			_, err = o.conn.Write(o.frame(msg))
+		}
+		if err == nil {
+			return
+		}
+
+		_ = o.conn.Close()
+		o.conn = nil
+	}
+	return
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Writer: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// connect
+// dial if connection not created, dial is not allowed until backoff
+// duration elapsed since last failure.
+func (o *writer) connect() (err error) {
+	if o.conn != nil {
+		return
+	}
+
+	if now := time.Now(); now.Before(o.nextDial) {
+		return fmt.Errorf("syslog: reconnect after %s", o.nextDial.Sub(now))
+	}
+
+	if o.conn, err = o.dial(); err != nil {
+		// Exponential
+		// backoff between failures.
+		if o.backoff == 0 {
+			o.backoff = o.backoffMin
+		} else if o.backoff *= 2; o.backoff > o.backoffMax {
+			o.backoff = o.backoffMax
+		}
+		o.nextDial = time.Now().Add(o.backoff)
+		return
+	}
+
+	o.backoff = 0
+	return
+}
+
+func (o *writer) dial() (net.Conn, error) {
+	switch o.network {
+	case "":
+		for _, addr := range o.localAddresses() {
+			for _, network := range []string{"unixgram", "unix"} {
+				if conn, err := net.DialTimeout(network, addr, o.timeout); err == nil {
+					return conn, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("syslog: local syslog socket not found")
+
+	case "tls":
+		return tls.DialWithDialer(&net.Dialer{Timeout: o.timeout}, "tcp", o.address, o.tls)
+	}
+
+	return net.DialTimeout(o.network, o.address, o.timeout)
+}
+
+// frame
+// returns framed message. Octet-counting or non-transparent framing
+// used on stream transport.
+func (o *writer) frame(msg string) []byte {
+	switch o.network {
+	case "tcp", "tcp4", "tcp6", "tls", "unix":
+		if o.framing == config.SyslogNonTransparent {
+			return []byte(msg + "\n")
+		}
+		return []byte(fmt.Sprintf("%d %s", len(msg), msg))
+	}
+	return []byte(msg)
+}
+
+func (o *writer) init(c config.SyslogLoggerConfiguration) (*writer, error) {
+	o.address = c.GetAddress()
+	o.backoffMax = time.Duration(c.GetReconnectMax()) * time.Millisecond
+	o.backoffMin = time.Duration(c.GetReconnectMin()) * time.Millisecond
+	o.framing = c.GetFraming()
+	o.network = c.GetNetwork()
+	o.timeout = time.Duration(c.GetTimeout()) * time.Millisecond
+
+	if o.network == "tls" {
+		return o, o.initTls(c.GetTls())
+	}
+	return o, nil
+}
+
+func (o *writer) initTls(c config.SyslogTlsConfiguration) error {
+	o.tls = &tls.Config{
+		InsecureSkipVerify: c.GetInsecureSkipVerify(),
+		ServerName:         c.GetServerName(),
+	}
+
+	if c.GetCaFile() != "" {
+		buf, err := os.ReadFile(c.GetCaFile())
+		if err != nil {
+			return err
+		}
+		o.tls.RootCAs = x509.NewCertPool()
+		if !o.tls.RootCAs.AppendCertsFromPEM(buf) {
+			return fmt.Errorf("syslog: invalid ca file: %s", c.GetCaFile())
+		}
+	}
+
+	if c.GetCertFile() != "" {
+		cert, err := tls.LoadX509KeyPair(c.GetCertFile(), c.GetKeyFile())
+		if err != nil {
+			return err
+		}
+		o.tls.Certificates = []tls.Certificate{cert}
+	}
+	return nil
+}
+
+func (o *writer) localAddresses() []string {
+	if o.address != "" {
+		return []string{o.address}
+	}
+	return localAddresses
+}
@@ -17,9 +17,12 @@ package logger_term
 
 import (
 	"context"
-	"fmt"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/exporters/logfmt"
 	"github.com/fuyibing/log/tracer"
+	"io"
 	"os"
+	"time"
 )
 
 type (
@@ -29,13 +32,95 @@ type (
 		Stopped() bool
 	}
 
+	// Option
+	// custom exporter options.
+	Option func(o *exporter)
+
 	exporter struct {
-		formatter Formatter
+		color       config.ColorMode
+		errorWriter io.Writer
+		formatter   Formatter
+		text        *formatter
+		writer      io.Writer
 	}
 )
 
-func New() Exporter {
-	return (&exporter{}).init()
+// New
+// returns a terminal logger exporter, configured by term-logger
+// section of log.yaml.
+func New(opts ...Option) Exporter {
+	o := (&exporter{}).init()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o.build()
+}
+
+// WithColor
+// specify color mode, accepts: auto, always, never.
+func WithColor(mode config.ColorMode) Option {
+	return func(o *exporter) { o.color = mode }
+}
+
+// WithErrorWriter
+// use specified writer for ERROR and more severe levels.
+func WithErrorWriter(w io.Writer) Option {
+	return func(o *exporter) { o.errorWriter = w }
+}
+
+// WithFormatter
+// use specified formatter, such as NewJsonFormatter(), logfmt.New().
+func WithFormatter(f Formatter) Option {
+	return func(o *exporter) {
+		if f != nil {
+			o.formatter = f
+		}
+	}
+}
+
+// WithTemplate
+// use specified template for text line.
+//
+//	logger_term.WithTemplate("{time} {level:5} {trace_id} {caller} {msg} {fields}")
+func WithTemplate(s string) Option {
+	return func(o *exporter) {
+		if s != "" {
+			o.text.template = s
+		}
+	}
+}
+
+// WithTheme
+// override colors of levels.
+func WithTheme(theme Theme) Option {
+	return func(o *exporter) {
+		for level, c := range theme {
+			o.text.theme[level] = c
+		}
+	}
+}
+
+// WithTimeFormat
+// use specified layout and location of time.
+func WithTimeFormat(layout string, loc *time.Location) Option {
+	return func(o *exporter) {
+		if layout != "" {
+			o.text.timeFormat = layout
+		}
+		if loc != nil {
+			o.text.location = loc
+		}
+	}
+}
+
+// WithWriter
+// use specified writer, stdout as default.
+func WithWriter(w io.Writer) Option {
+	return func(o *exporter) {
+		if w != nil {
+			o.writer = w
+		}
+	}
 }
 
 func (o *exporter) Push(log *tracer.Log) error {
@@ -53,17 +138,97 @@ func (o *exporter) Stopped() bool { return true }
 // Exporter: constructor
 // /////////////////////////////////////////////////////////////////////////////
 
+// build
+// apply options on text formatter.
+func (o *exporter) build() *exporter {
+	if o.formatter == nil {
+		o.text.colored = o.colored()
+		o.formatter = o.text.compile()
+	}
+	if o.errorWriter == nil {
+		o.errorWriter = o.writer
+	}
+	return o
+}
+
+// colored
+// return true if colors enabled. In auto mode, colors stripped if
+// writer is not a terminal or NO_COLOR environment variable set.
+func (o *exporter) colored() bool {
+	switch o.color {
+	case config.ColorAlways:
+		return true
+	case config.ColorNever:
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if f, ok := o.writer.(*os.File); ok {
+		return isTerminal(f)
+	}
+	return false
+}
+
 func (o *exporter) init() *exporter {
-	o.formatter = (&formatter{}).init()
+	c := config.Config.GetTermLogger()
+
+	o.color = c.GetColor()
+	o.writer = writerOf(c.GetWriter())
+	if c.GetErrorWriter() != "" {
+		o.errorWriter = writerOf(c.GetErrorWriter())
+	}
+
+	// Text formatter
+	// configured by yaml, options can override.
+	o.text = (&formatter{}).init()
+	o.text.template = c.GetTemplate()
+	o.text.timeFormat = c.GetTimeFormat()
+	if c.GetTimeZone() != "" {
+		if loc, err := time.LoadLocation(c.GetTimeZone()); err == nil {
+			o.text.location = loc
+		}
+	}
+	for level, color := range c.GetTheme() {
+		o.text.theme[level] = color
+	}
+
+	switch c.GetFormat() {
+	case config.FormatJson:
+		o.formatter = NewJsonFormatter(c.GetAttributes()...)
+	case config.FormatLogfmt:
+		o.formatter = logfmt.New(c.GetAttributes()...)
+	}
 	return o
 }
 
 // println
-// print log content on terminal.
+// print log content on terminal, ERROR and more severe levels
+// printed on error writer.
 func (o *exporter) println(log *tracer.Log) {
-	_, _ = fmt.Fprintf(os.Stdout,
-		fmt.Sprintf("%s\n",
-			o.formatter.Format(log),
-		),
-	)
+	w := o.writer
+	if i := log.Level.Int(); i > config.Off.Int() && i <= config.Error.Int() {
+		w = o.errorWriter
+	}
+	_, _ = io.WriteString(w, o.formatter.Format(log)+"\n")
+}
+
+// isTerminal
+// return true if file is a character device, colors are stripped
+// if stdout redirected to file or pipe.
+func isTerminal(f *os.File) bool {
+	if info, err := f.Stat(); err == nil {
+		return info.Mode()&os.ModeCharDevice != 0
+	}
+	return false
+}
+
+// writerOf
+// returns a writer by name, accepts: stdout, stderr.
+func writerOf(name string) io.Writer {
+	if name == config.WriterStderr {
+		return os.Stderr
+	}
+	return os.Stdout
 }
@@ -19,16 +19,10 @@ import (
 	"fmt"
 	"github.com/fuyibing/log/config"
 	"github.com/fuyibing/log/tracer"
-)
-
-var (
-	colors = map[config.LoggerLevel][]int{
-		config.Debug: {37, 0},  // Text: gray, Background: white
-		config.Info:  {34, 0},  // Text: blue, Background: white
-		config.Warn:  {33, 0},  // Text: yellow, Background: white
-		config.Error: {31, 0},  // Text: red, Background: white
-		config.Fatal: {33, 41}, // Text: yellow, Background: red
-	}
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type (
@@ -36,20 +30,68 @@ type (
 		Format(log *tracer.Log) string
 	}
 
+	// Theme
+	// colors of levels, text color at index 0 and background color
+	// at index 1.
+	Theme map[config.LoggerLevel][]int
+
 	formatter struct {
+		colored    bool
+		location   *time.Location
+		segments   []segment
+		template   string
+		theme      Theme
+		timeFormat string
+	}
+
+	// segment
+	// part of compiled template, a literal string or a placeholder
+	// with width.
+	segment struct {
+		literal     string
+		placeholder string
+		width       int
 	}
 )
 
+// NewTextFormatter
+// returns a formatter generate log as text line, colors enabled by
+// colored param.
+func NewTextFormatter(colored bool) Formatter {
+	return (&formatter{colored: colored}).init()
+}
+
 // Format
 // generate log as string used on terminal.
 func (o *formatter) Format(log *tracer.Log) (text string) {
-	text = fmt.Sprintf("[%-15s][%5s] %s",
-		log.Time.Format("15:04:05.999999"),
-		log.Level.String(),
-		log.Text,
-	)
+	buf := &strings.Builder{}
+	for _, seg := range o.segments {
+		if seg.placeholder == "" {
+			buf.WriteString(seg.literal)
+			continue
+		}
+		if seg.width != 0 {
+			buf.WriteString(fmt.Sprintf("%*s", seg.width, o.value(log, seg.placeholder)))
+		} else {
+			buf.WriteString(o.value(log, seg.placeholder))
+		}
+	}
+	text = buf.String()
+
+	if !o.colored {
+		return
+	}
 
-	if c, ok := colors[log.Level]; ok {
+	// Colors
+	// of theme or registered with level, see config.RegisterLevel().
+	c, ok := o.theme[log.Level]
+	if !ok || len(c) == 0 {
+		c, ok = log.Level.Color()
+	}
+	if ok && len(c) > 0 {
+		if len(c) == 1 {
+			c = []int{c[0], 0}
+		}
 		text = fmt.Sprintf("%c[%d;%d;%dm%s%c[0m",
 			0x1B, 0, c[1], c[0], text, 0x1B,
 		)
@@ -62,6 +104,92 @@ func (o *formatter) Format(log *tracer.Log) (text string) {
 // Formatter: constructor
 // /////////////////////////////////////////////////////////////////////////////
 
-func (o *formatter) init() *formatter {
+// compile
+// parse template into segments.
+//
+//	"[{time:-15}][{level:5}] {msg}"
+func (o *formatter) compile() *formatter {
+	var (
+		str  = o.template
+		list = make([]segment, 0)
+	)
+
+	for str != "" {
+		i := strings.Index(str, "{")
+		j := strings.Index(str, "}")
+
+		// Literal
+		// remained.
+		if i < 0 || j < i {
+			list = append(list, segment{literal: str})
+			break
+		}
+
+		if i > 0 {
+			list = append(list, segment{literal: str[:i]})
+		}
+
+		seg := segment{placeholder: str[i+1 : j]}
+		if k := strings.Index(seg.placeholder, ":"); k > 0 {
+			seg.width, _ = strconv.Atoi(seg.placeholder[k+1:])
+			seg.placeholder = seg.placeholder[:k]
+		}
+		list = append(list, seg)
+		str = str[j+1:]
+	}
+
+	o.segments = list
 	return o
 }
+
+func (o *formatter) init() *formatter {
+	o.location = time.Local
+	o.template = config.DefaultTermLoggerTemplate
+	o.theme = Theme{}
+	o.timeFormat = config.DefaultTermLoggerTimeFormat
+	return o.compile()
+}
+
+// value
+// returns a string of placeholder.
+func (o *formatter) value(log *tracer.Log, placeholder string) string {
+	switch placeholder {
+	case "time":
+		return log.Time.In(o.location).Format(o.timeFormat)
+	case "level":
+		return log.Level.String()
+	case "msg":
+		return log.Text
+	case "caller":
+		return log.Caller
+	case "trace_id":
+		if !log.TraceId.IsZero() {
+			return log.TraceId.String()
+		}
+	case "span_id":
+		if !log.TraceId.IsZero() {
+			return log.SpanId.String()
+		}
+	case "fields":
+		return o.fields(log.Fields)
+	default:
+		return "{" + placeholder + "}"
+	}
+	return ""
+}
+
+// fields
+// returns key=value pairs sorted by key.
+func (o *formatter) fields(attr tracer.Attr) string {
+	keys := make([]string, 0, len(attr))
+	for k := range attr {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	list := make([]string, 0, len(keys))
+	for _, k := range keys {
+		list = append(list, fmt.Sprintf("%s=%v", k, attr[k]))
+	}
+	return strings.Join(list, " ")
+}
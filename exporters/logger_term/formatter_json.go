@@ -0,0 +1,92 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_term
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/fuyibing/log/tracer"
+	"time"
+)
+
+type (
+	jsonFormatter struct {
+		attributes []string
+	}
+)
+
+// NewJsonFormatter
+// returns a formatter generate log as a json object per line, provider
+// attributes of specified keys included.
+func NewJsonFormatter(attributes ...string) Formatter {
+	return (&jsonFormatter{attributes: attributes}).init()
+}
+
+// Format
+// generate log as json object with fixed key order.
+//
+//	{"time":"...","level":"INFO","msg":"...","trace_id":"...","span_id":"...","fields":{},"service.name":"..."}
+func (o *jsonFormatter) Format(log *tracer.Log) string {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+
+	o.write(buf, "time", log.Time.Format(time.RFC3339Nano), true)
+	o.write(buf, "level", log.Level.String(), false)
+	o.write(buf, "msg", log.Text, false)
+
+	if !log.TraceId.IsZero() {
+		o.write(buf, "trace_id", log.TraceId.String(), false)
+		o.write(buf, "span_id", log.SpanId.String(), false)
+	}
+
+	if len(log.Fields) > 0 {
+		o.write(buf, "fields", log.Fields, false)
+	}
+
+	// Provider attributes
+	// of log in configured order.
+	for _, key := range o.attributes {
+		if v, ok := log.Attr[key]; ok {
+			o.write(buf, key, v, false)
+		}
+	}
+
+	buf.WriteByte('}')
+	return buf.String()
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Formatter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *jsonFormatter) init() *jsonFormatter {
+	return o
+}
+
+func (o *jsonFormatter) write(buf *bytes.Buffer, key string, value interface{}, first bool) {
+	k, _ := json.Marshal(key)
+	v, err := json.Marshal(value)
+	if err != nil {
+		v, _ = json.Marshal(err.Error())
+	}
+
+	if !first {
+		buf.WriteByte(',')
+	}
+	buf.Write(k)
+	buf.WriteByte(':')
+	buf.Write(v)
+}
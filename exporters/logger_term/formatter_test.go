@@ -0,0 +1,121 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package logger_term
+
+import (
+	"encoding/json"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"github.com/fuyibing/log/tracetest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJsonFormatter_Format(t *testing.T) {
+	tr := tracer.Provider.NewTrace("json")
+
+	x := tracer.NewLog(tracer.LogSpan, config.Info)
+	x.Attr = tracer.Attr{"service.name": "term-test"}
+	x.Text = "line 1\nline \"2\""
+	x.Fields = tracer.Attr{"user": 1}
+	x.TraceId = tr.GetTraceId()
+	x.SpanId = tr.GetSpanId()
+
+	str := NewJsonFormatter("service.name", "not.exists").Format(x)
+	if strings.Contains(str, "\n") {
+		t.Fatalf("json line contains newline: %s", str)
+	}
+
+	m := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(str), &m); err != nil {
+		t.Fatalf("invalid json: %s, %v", str, err)
+	}
+	if m["msg"] != x.Text || m["level"] != "INFO" || m["trace_id"] != tr.GetTraceId().String() || m["service.name"] != "term-test" {
+		t.Errorf("unexpected json: %s", str)
+	}
+	if !strings.HasPrefix(str, `{"time":`) {
+		t.Errorf("time should be the first key: %s", str)
+	}
+}
+
+func TestJsonFormatter_Fields(t *testing.T) {
+	r := tracetest.New(t)
+	r.Provider.SetAttr("service.name", "recorder")
+	r.NewTrace("fields").NewSpan("login").LogWith(config.Info, tracer.Attr{"user": 1}, "user login")
+
+	logs := r.Logs()
+	if len(logs) != 1 {
+		t.Fatalf("expect 1 log, got %d", len(logs))
+	}
+
+	m := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(NewJsonFormatter("service.name").Format(logs[0])), &m); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if fields, ok := m["fields"].(map[string]interface{}); !ok || fields["user"] != float64(1) {
+		t.Errorf("expect fields of log, got %v", m["fields"])
+	}
+
+	// Attributes
+	// of provider which log sent by, not global provider.
+	if m["service.name"] != "recorder" {
+		t.Errorf("expect attributes of recorder provider, got %v", m["service.name"])
+	}
+}
+
+func TestTextFormatter_Format(t *testing.T) {
+	x := tracer.NewLog(tracer.LogInternal, config.Error)
+	x.Text = "error"
+
+	if str := NewTextFormatter(false).Format(x); strings.Contains(str, "\x1b") {
+		t.Errorf("colors should be stripped: %q", str)
+	}
+	if str := NewTextFormatter(true).Format(x); !strings.Contains(str, "\x1b") {
+		t.Errorf("colors expected: %q", str)
+	}
+}
+
+func TestExporter_Options(t *testing.T) {
+	var (
+		out, errs = &strings.Builder{}, &strings.Builder{}
+		loc       = time.FixedZone("UTC+8", 8*3600)
+	)
+
+	ex := New(
+		WithWriter(out),
+		WithErrorWriter(errs),
+		WithTemplate("{time} {level:-6}|{trace_id}|{msg} {fields} {unknown}"),
+		WithTimeFormat("2006-01-02T15:04", loc),
+		WithColor(config.ColorAuto),
+	)
+
+	x := tracer.NewLog(tracer.LogInternal, config.Info)
+	x.Time = time.Date(2023, 2, 24, 0, 30, 0, 0, time.UTC)
+	x.Text = "text"
+	x.Fields = tracer.Attr{"b": 2, "a": 1}
+	_ = ex.Push(x)
+
+	x = tracer.NewLog(tracer.LogInternal, config.Error)
+	_ = ex.Push(x)
+
+	if expect := "2023-02-24T08:30 INFO  ||text a=1 b=2 {unknown}\n"; out.String() != expect {
+		t.Errorf("expect %q, got %q", expect, out.String())
+	}
+	if !strings.Contains(errs.String(), "ERROR") || strings.Contains(errs.String(), "\x1b") {
+		t.Errorf("error log should be printed without colors on error writer: %q", errs.String())
+	}
+}
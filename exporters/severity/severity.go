@@ -0,0 +1,46 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+// Package severity
+// map levels to severities of syslog, used by syslog, journald and
+// gelf logger.
+package severity
+
+import (
+	"github.com/fuyibing/log/config"
+)
+
+// Syslog
+// returns syslog severity of level, custom level mapped by integer.
+//
+//	FATAL: 2 (critical), ERROR: 3, WARN: 4, NOTICE: 5, INFO: 6, DEBUG/TRACE: 7
+func Syslog(level config.LoggerLevel) int {
+	i := level.Int()
+	switch {
+	case i <= config.Off.Int():
+		return 7
+	case i <= config.Fatal.Int():
+		return 2
+	case i <= config.Error.Int():
+		return 3
+	case i <= config.Warn.Int():
+		return 4
+	case i <= config.Notice.Int():
+		return 5
+	case i <= config.Info.Int():
+		return 6
+	}
+	return 7
+}
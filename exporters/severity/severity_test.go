@@ -0,0 +1,38 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package severity
+
+import (
+	"github.com/fuyibing/log/config"
+	"testing"
+)
+
+func TestSyslog(t *testing.T) {
+	for level, n := range map[config.LoggerLevel]int{
+		config.Fatal:  2,
+		config.Error:  3,
+		config.Warn:   4,
+		config.Notice: 5,
+		config.Info:   6,
+		config.Debug:  7,
+		config.Trace:  7,
+		"UNKNOWN":     7,
+	} {
+		if v := Syslog(level); v != n {
+			t.Errorf("severity of %s: expect %d, got %d", level, n, v)
+		}
+	}
+}
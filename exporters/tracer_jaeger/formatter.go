@@ -71,18 +71,37 @@ func (o *formatter) buildLogs(list []*tracer.Log) []*jaeger.Log {
 	logs := make([]*jaeger.Log, 0)
 
 	for _, x := range list {
+		attr := (tracer.Attr{}).
+			Add(x.Level.String(), x.Text).
+			Add("level", x.Level.String()).
+			Add("time", x.Time)
+
+		// Mark error
+		// for error and more severe levels, custom level supported.
+		if i := x.Level.Int(); i > config.Off.Int() && i <= config.Error.Int() {
+			attr.Add("error", true)
+		}
+
 		logs = append(logs, &jaeger.Log{
-			Timestamp: x.Time.UnixMicro(), Fields: o.buildTags(
-				(tracer.Attr{}).
-					Add(x.Level.String(), x.Text).
-					Add("time", x.Time),
-			),
+			Timestamp: x.Time.UnixMicro(), Fields: o.buildTags(attr),
 		})
 	}
 
 	return logs
 }
 
+// buildFlags
+// returns jaeger flags of trace, 1 for sampled and 2 for debug.
+func (o *formatter) buildFlags(tr tracer.Trace) (flags int32) {
+	if tr.GetSampled() {
+		flags |= 1
+	}
+	if tr.GetDebug() {
+		flags |= 2
+	}
+	return
+}
+
 func (o *formatter) buildProcess(sp tracer.Span) *jaeger.Process {
 	return &jaeger.Process{
 		ServiceName: config.Config.GetTracerTopic(),
@@ -108,7 +127,7 @@ func (o *formatter) buildSpan(sp tracer.Span) *jaeger.Span {
 	span.OperationName = sp.GetName()
 	span.StartTime = sp.GetStartTime().UnixMicro()
 	span.Duration = sp.GetDuration().Microseconds()
-	span.Flags = 1
+	span.Flags = o.buildFlags(sp.GetTrace())
 
 	// Extensions.
 	span.Tags = o.buildTags(sp.GetAttr())
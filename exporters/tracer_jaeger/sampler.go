@@ -0,0 +1,372 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer_jaeger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// Sampler
+	// sampler of strategies fetched from jaeger compatible sampling
+	// endpoint, local sampler used until fetched or if endpoint is
+	// unreachable. Started and stopped with provider which it is set
+	// to, fetching errors reported as logs of the provider.
+	//
+	//	tracer.Provider.SetSampler(tracer.ParentBased(tracer_jaeger.NewSampler()))
+	//	_ = tracer.Provider.Start(ctx)
+	Sampler interface {
+		tracer.SamplerStarter
+
+		// Update
+		// fetch strategies once.
+		Update() error
+	}
+
+	SamplerOption func(o *sampler)
+
+	sampler struct {
+		sync.RWMutex
+
+		client      *http.Client
+		endpoint    string
+		fallback    tracer.Sampler
+		maxFailures int
+		refresh     time.Duration
+		service     string
+
+		body     []byte
+		failures int
+		strategy tracer.Sampler
+	}
+
+	// guaranteedSampler
+	// probabilistic sampler with a lower bound rate of traces.
+	guaranteedSampler struct {
+		lowerBound, probabilistic tracer.Sampler
+	}
+
+	// operationSampler
+	// samplers for each operation, operation is name of trace.
+	operationSampler struct {
+		defaults   tracer.Sampler
+		operations map[string]tracer.Sampler
+	}
+)
+
+const (
+	// defaultSamplerMaxFailures
+	// number of continuous failed fetching before fallback sampler used.
+	defaultSamplerMaxFailures = 3
+)
+
+type (
+	strategyResponse struct {
+		StrategyType          json.RawMessage        `json:"strategyType"`
+		ProbabilisticSampling *probabilisticStrategy `json:"probabilisticSampling"`
+		RateLimitingSampling  *rateLimitingStrategy  `json:"rateLimitingSampling"`
+		OperationSampling     *operationStrategy     `json:"operationSampling"`
+	}
+
+	probabilisticStrategy struct {
+		SamplingRate float64 `json:"samplingRate"`
+	}
+
+	rateLimitingStrategy struct {
+		MaxTracesPerSecond float64 `json:"maxTracesPerSecond"`
+	}
+
+	operationStrategy struct {
+		DefaultSamplingProbability       float64 `json:"defaultSamplingProbability"`
+		DefaultLowerBoundTracesPerSecond float64 `json:"defaultLowerBoundTracesPerSecond"`
+		PerOperationStrategies           []struct {
+			Operation             string                 `json:"operation"`
+			ProbabilisticSampling *probabilisticStrategy `json:"probabilisticSampling"`
+		} `json:"perOperationStrategies"`
+	}
+)
+
+// NewSampler
+// returns a remote sampler, endpoint and refresh interval default to
+// jaeger-trace configuration, service default to tracer-topic.
+func NewSampler(opts ...SamplerOption) Sampler {
+	o := (&sampler{}).init()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithSamplerClient
+// use specified http client.
+func WithSamplerClient(c *http.Client) SamplerOption {
+	return func(o *sampler) { o.client = c }
+}
+
+// WithSamplerEndpoint
+// use specified sampling endpoint, such as http://localhost:5778/sampling.
+func WithSamplerEndpoint(s string) SamplerOption {
+	return func(o *sampler) { o.endpoint = s }
+}
+
+// WithSamplerFallback
+// use specified sampler if strategies not fetched, default is
+// tracer.RuleBased().
+func WithSamplerFallback(s tracer.Sampler) SamplerOption {
+	return func(o *sampler) { o.fallback = s }
+}
+
+// WithSamplerMaxFailures
+// use fallback sampler after specified number of continuous failed
+// fetching, last fetched strategies used before. Ignored if n is not
+// positive.
+func WithSamplerMaxFailures(n int) SamplerOption {
+	return func(o *sampler) {
+		if n > 0 {
+			o.maxFailures = n
+		}
+	}
+}
+
+// WithSamplerRefresh
+// use specified interval between fetching. Ignored if d is not positive.
+func WithSamplerRefresh(d time.Duration) SamplerOption {
+	return func(o *sampler) {
+		if d > 0 {
+			o.refresh = d
+		}
+	}
+}
+
+// WithSamplerService
+// use specified service name in query.
+func WithSamplerService(s string) SamplerOption {
+	return func(o *sampler) { o.service = s }
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Sampler: interface
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *sampler) Sample(p tracer.SamplingParameters) bool {
+	o.RLock()
+	s := o.strategy
+	o.RUnlock()
+
+	if s == nil {
+		return o.fallback.Sample(p)
+	}
+	return s.Sample(p)
+}
+
+func (o *sampler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(o.refresh)
+	defer ticker.Stop()
+
+	for {
+		if err := o.Update(); err != nil {
+			o.report(ctx, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (o *sampler) String() string {
+	o.RLock()
+	s := o.strategy
+	o.RUnlock()
+
+	if s == nil {
+		return fmt.Sprintf("JaegerRemote{fallback:%s}", o.fallback)
+	}
+	return fmt.Sprintf("JaegerRemote{%s}", s)
+}
+
+// Update
+// fetch strategies. Last fetched strategies kept if failed, fallback
+// sampler used after max failures in a row. Samplers are not rebuilt
+// if strategies not changed, rate limiters keep credits.
+func (o *sampler) Update() error {
+	body, err := o.fetch()
+	if err != nil {
+		return o.fail(err)
+	}
+
+	o.RLock()
+	same := o.strategy != nil && bytes.Equal(o.body, body)
+	o.RUnlock()
+	if same {
+		o.Lock()
+		o.failures = 0
+		o.Unlock()
+		return nil
+	}
+
+	s, err := o.parse(body)
+	if err != nil {
+		return o.fail(err)
+	}
+
+	o.Lock()
+	o.body, o.failures, o.strategy = body, 0, s
+	o.Unlock()
+	return nil
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Sampler: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// fail
+// count a failed fetching, strategies discarded if max failures
+// reached.
+func (o *sampler) fail(err error) error {
+	o.Lock()
+	defer o.Unlock()
+
+	if o.failures++; o.failures >= o.maxFailures {
+		o.body, o.strategy = nil, nil
+	}
+	return err
+}
+
+func (o *sampler) fetch() ([]byte, error) {
+	sep := "?"
+	if strings.Contains(o.endpoint, "?") {
+		sep = "&"
+	}
+
+	res, err := o.client.Get(o.endpoint + sep + "service=" + url.QueryEscape(o.service))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d: %s", res.StatusCode, bytes.TrimSpace(body))
+	}
+	return body, nil
+}
+
+func (o *sampler) init() *sampler {
+	c := config.Config.GetJaegerTrace()
+
+	o.client = &http.Client{Timeout: time.Duration(c.GetSamplingTimeout()) * time.Millisecond}
+	o.endpoint = c.GetSamplingEndpoint()
+	o.fallback = tracer.RuleBased()
+	o.maxFailures = defaultSamplerMaxFailures
+	o.refresh = time.Duration(c.GetSamplingRefresh()) * time.Millisecond
+	o.service = config.Config.GetTracerTopic()
+	return o
+}
+
+// parse
+// returns sampler of strategies. Per-operation strategies take
+// precedence, strategy type is a name or an integer of legacy agent.
+func (o *sampler) parse(body []byte) (tracer.Sampler, error) {
+	res := &strategyResponse{}
+	if err := json.Unmarshal(body, res); err != nil {
+		return nil, err
+	}
+
+	if x := res.OperationSampling; x != nil {
+		s := &operationSampler{
+			defaults:   o.guaranteed(x.DefaultSamplingProbability, x.DefaultLowerBoundTracesPerSecond),
+			operations: make(map[string]tracer.Sampler),
+		}
+		for _, op := range x.PerOperationStrategies {
+			if op.ProbabilisticSampling != nil {
+				s.operations[op.Operation] = o.guaranteed(op.ProbabilisticSampling.SamplingRate, x.DefaultLowerBoundTracesPerSecond)
+			}
+		}
+		return s, nil
+	}
+
+	switch t := strings.Trim(string(res.StrategyType), `"`); t {
+	case "RATE_LIMITING", "1":
+		if res.RateLimitingSampling != nil {
+			return tracer.RateLimiting(res.RateLimitingSampling.MaxTracesPerSecond), nil
+		}
+
+	case "PROBABILISTIC", "0", "":
+		if res.ProbabilisticSampling != nil {
+			return tracer.TraceIdRatio(res.ProbabilisticSampling.SamplingRate), nil
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown strategy type: %s", t)
+	}
+	return nil, fmt.Errorf("strategy not found")
+}
+
+func (o *sampler) guaranteed(rate, lowerBound float64) tracer.Sampler {
+	if lowerBound <= 0 {
+		return tracer.TraceIdRatio(rate)
+	}
+	return &guaranteedSampler{lowerBound: tracer.RateLimiting(lowerBound), probabilistic: tracer.TraceIdRatio(rate)}
+}
+
+// report
+// send error as log of provider which started sampler.
+func (o *sampler) report(ctx context.Context, err error) {
+	tracer.ProviderFromContext(ctx).PushBaseLog(config.Error, "jaeger sampler: %v", err)
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Strategies
+// /////////////////////////////////////////////////////////////////////////////
+
+// Sample
+// return true if probabilistic sampled, credit of lower bound is not
+// consumed in this case.
+func (o *guaranteedSampler) Sample(p tracer.SamplingParameters) bool {
+	return o.probabilistic.Sample(p) || o.lowerBound.Sample(p)
+}
+
+func (o *guaranteedSampler) String() string {
+	return fmt.Sprintf("Guaranteed{%s,lowerBound:%s}", o.probabilistic, o.lowerBound)
+}
+
+func (o *operationSampler) Sample(p tracer.SamplingParameters) bool {
+	if s, ok := o.operations[p.Name]; ok {
+		return s.Sample(p)
+	}
+	return o.defaults.Sample(p)
+}
+
+func (o *operationSampler) String() string {
+	return fmt.Sprintf("PerOperation{operations:%d,default:%s}", len(o.operations), o.defaults)
+}
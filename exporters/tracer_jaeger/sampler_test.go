@@ -0,0 +1,199 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer_jaeger
+
+import (
+	"context"
+	"github.com/fuyibing/log/tracer"
+	"github.com/fuyibing/log/tracetest"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testAgent struct {
+	sync.Mutex
+	body, service string
+	status        int
+}
+
+func (o *testAgent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	o.Lock()
+	defer o.Unlock()
+
+	o.service = r.URL.Query().Get("service")
+	if o.status != 0 {
+		w.WriteHeader(o.status)
+	}
+	_, _ = w.Write([]byte(o.body))
+}
+
+func (o *testAgent) set(status int, body string) {
+	o.Lock()
+	o.body, o.status = body, status
+	o.Unlock()
+}
+
+func TestSamplerStrategies(t *testing.T) {
+	agent := &testAgent{}
+	server := httptest.NewServer(agent)
+	defer server.Close()
+
+	s := NewSampler(WithSamplerEndpoint(server.URL+"/sampling"), WithSamplerService("orders"), WithSamplerFallback(tracer.AlwaysOff()))
+	p := tracer.SamplingParameters{Name: "GET /orders", TraceId: tracer.Identify.NewTraceId()}
+
+	// Fallback
+	// before fetched.
+	if s.Sample(p) {
+		t.Fatalf("expect fallback used before fetched")
+	}
+
+	agent.set(0, `{"strategyType":"PROBABILISTIC","probabilisticSampling":{"samplingRate":1}}`)
+	if err := s.Update(); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if agent.service != "orders" || !s.Sample(p) {
+		t.Errorf("expect probabilistic strategy applied for service orders, got %s", agent.service)
+	}
+
+	// Rate limiting
+	// strategy type of legacy agent.
+	agent.set(0, `{"strategyType":1,"rateLimitingSampling":{"maxTracesPerSecond":1}}`)
+	if err := s.Update(); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !s.Sample(p) || s.Sample(p) {
+		t.Errorf("expect rate limiting strategy applied")
+	}
+
+	// Unchanged
+	// strategies keep credits of rate limiter.
+	if err := s.Update(); err != nil || s.Sample(p) {
+		t.Errorf("expect rate limiter kept: %v", err)
+	}
+
+	agent.set(0, `{"strategyType":"PROBABILISTIC","operationSampling":{
+		"defaultSamplingProbability":0,
+		"defaultLowerBoundTracesPerSecond":1,
+		"perOperationStrategies":[{"operation":"GET /orders","probabilisticSampling":{"samplingRate":1}}]}}`)
+	if err := s.Update(); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !s.Sample(p) || !s.Sample(p) {
+		t.Errorf("expect operation strategy applied")
+	}
+
+	other := tracer.SamplingParameters{Name: "GET /health", TraceId: tracer.Identify.NewTraceId()}
+	if !s.Sample(other) || s.Sample(other) {
+		t.Errorf("expect default strategy with lower bound")
+	}
+
+	// Unreachable
+	// endpoint, last strategies kept until max failures.
+	agent.set(http.StatusInternalServerError, "down")
+	for i := 1; i < defaultSamplerMaxFailures; i++ {
+		if err := s.Update(); err == nil || !s.Sample(p) {
+			t.Errorf("expect last strategies kept on failure %d: %v", i, err)
+		}
+	}
+	if err := s.Update(); err == nil || s.Sample(p) {
+		t.Errorf("expect fallback used after max failures: %v", err)
+	}
+}
+
+func TestSamplerFailures(t *testing.T) {
+	agent := &testAgent{body: `{"probabilisticSampling":{"samplingRate":1}}`}
+	server := httptest.NewServer(agent)
+	defer server.Close()
+
+	s := NewSampler(WithSamplerEndpoint(server.URL), WithSamplerMaxFailures(2), WithSamplerFallback(tracer.AlwaysOff()))
+	p := tracer.SamplingParameters{TraceId: tracer.Identify.NewTraceId()}
+	if err := s.Update(); err != nil || !s.Sample(p) {
+		t.Fatalf("expect strategies fetched: %v", err)
+	}
+
+	// Recovered
+	// resets count of failures.
+	agent.set(0, "invalid")
+	if err := s.Update(); err == nil || !s.Sample(p) {
+		t.Errorf("expect last strategies kept on invalid body: %v", err)
+	}
+	agent.set(0, `{"probabilisticSampling":{"samplingRate":1}}`)
+	if err := s.Update(); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	agent.set(http.StatusBadGateway, "")
+	if err := s.Update(); err == nil || !s.Sample(p) {
+		t.Errorf("expect last strategies kept after recovered: %v", err)
+	}
+	if err := s.Update(); err == nil || s.Sample(p) {
+		t.Errorf("expect fallback used after 2 failures: %v", err)
+	}
+}
+
+func TestSamplerRefresh(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Second} {
+		s := NewSampler(WithSamplerRefresh(d), WithSamplerMaxFailures(int(d))).(*sampler)
+		if s.refresh <= 0 || s.maxFailures != defaultSamplerMaxFailures {
+			t.Errorf("expect defaults kept for %s, got %s, %d", d, s.refresh, s.maxFailures)
+		}
+	}
+}
+
+func TestSamplerStart(t *testing.T) {
+	agent := &testAgent{body: `{"probabilisticSampling":{"samplingRate":1}}`}
+	server := httptest.NewServer(agent)
+
+	var (
+		logs     = tracetest.NewLoggerExporter()
+		provider = tracer.NewProvider()
+		s        = NewSampler(WithSamplerEndpoint(server.URL), WithSamplerRefresh(10*time.Millisecond), WithSamplerFallback(tracer.AlwaysOff()))
+	)
+
+	// Started
+	// with provider, as root of parent based sampler.
+	provider.SetLoggerExporter(logs)
+	provider.SetSampler(tracer.ParentBased(s))
+	if err := provider.Start(context.Background()); err != nil {
+		t.Fatalf("start error: %v", err)
+	}
+
+	p := tracer.SamplingParameters{TraceId: tracer.Identify.NewTraceId()}
+	deadline := time.Now().Add(time.Second)
+	for !s.Sample(p) && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !s.Sample(p) {
+		t.Errorf("expect strategies fetched on start")
+	}
+
+	// Close
+	// server, fallback used on next refresh.
+	server.Close()
+	for s.Sample(p) && time.Now().Before(deadline.Add(time.Second)) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if s.Sample(p) {
+		t.Errorf("expect fallback used if endpoint unreachable")
+	}
+
+	provider.Stop()
+	if len(logs.Logs()) == 0 {
+		t.Errorf("expect fetching errors reported on provider")
+	}
+}
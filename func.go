@@ -17,8 +17,16 @@ package log
 
 import (
 	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
 )
 
+// Trace send trace level log to Provider.
+func Trace(text string, args ...interface{}) {
+	if config.Config.TraceOn() {
+		Provider.PushBaseLog(config.Trace, text, args...)
+	}
+}
+
 // Debug send debug level log to Provider.
 func Debug(text string, args ...interface{}) {
 	if config.Config.DebugOn() {
@@ -33,6 +41,13 @@ func Info(text string, args ...interface{}) {
 	}
 }
 
+// Notice send notice level log to Provider.
+func Notice(text string, args ...interface{}) {
+	if config.Config.NoticeOn() {
+		Provider.PushBaseLog(config.Notice, text, args...)
+	}
+}
+
 // Warn send warn level log to Provider.
 func Warn(text string, args ...interface{}) {
 	if config.Config.WarnOn() {
@@ -53,3 +68,19 @@ func Fatal(text string, args ...interface{}) {
 		Provider.PushBaseLog(config.Fatal, text, args...)
 	}
 }
+
+// Log send specified level log to Provider, custom level supported.
+func Log(level config.LoggerLevel, text string, args ...interface{}) {
+	if config.Config.LevelOn(level) {
+		Provider.PushBaseLog(level, text, args...)
+	}
+}
+
+// LogWith send specified level log with structured fields to Provider.
+//
+//	log.LogWith(config.Info, tracer.Attr{"user": 1}, "user login")
+func LogWith(level config.LoggerLevel, fields tracer.Attr, text string, args ...interface{}) {
+	if config.Config.LevelOn(level) {
+		Provider.PushBaseLogWith(level, fields, text, args...)
+	}
+}
@@ -5,5 +5,6 @@ go 1.13
 require (
 	github.com/valyala/fasthttp v1.44.0
 	go.opentelemetry.io/otel/exporters/jaeger v1.13.0
+	golang.org/x/sys v0.7.0
 	gopkg.in/yaml.v3 v3.0.1
 )
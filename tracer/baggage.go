@@ -0,0 +1,279 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"context"
+	"github.com/fuyibing/log/config"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+type (
+	// Baggage
+	// key/value pairs propagated with trace context across services.
+	Baggage map[string]string
+
+	// BaggagePropagator
+	// W3C baggage header, properties of members are discarded.
+	//
+	//	baggage: tenant_id=t1,experiment=new%20checkout
+	BaggagePropagator struct{}
+
+	// JaegerBaggagePropagator
+	// jaeger uberctx-{key} headers, a header for each member.
+	//
+	//	uberctx-tenant_id: t1
+	JaegerBaggagePropagator struct{}
+)
+
+const (
+	headerBaggage       = "baggage"
+	headerJaegerBaggage = "uberctx-"
+)
+
+// BaggageFromContext
+// returns a copy of baggage stored in ctx, never return nil.
+func BaggageFromContext(ctx context.Context) Baggage {
+	b := Baggage{}
+	if ctx != nil {
+		if v, ok := ctx.Value(contextKeyBaggage).(Baggage); ok {
+			for k, x := range v {
+				b[k] = x
+			}
+		}
+	}
+	return b
+}
+
+// WithBaggage
+// returns a copy of ctx with baggage member added, spans created with
+// returned context and outgoing requests injected with it carry the
+// member. Return ctx if key is invalid or limits of configuration
+// exceeded.
+//
+//	ctx = tracer.WithBaggage(ctx, "tenant_id", "t1")
+func WithBaggage(ctx context.Context, key, value string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !isBaggageKey(key) {
+		return ctx
+	}
+
+	b := BaggageFromContext(ctx)
+	b[key] = value
+
+	if c := config.Config.GetBaggage(); len(b) > c.GetMaxMembers() || len(b.encode()) > c.GetMaxBytes() {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKeyBaggage, b)
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Baggage: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// encode
+// returns W3C baggage header value, members sorted by key.
+func (o Baggage) encode() string {
+	keys := make([]string, 0, len(o))
+	for k := range o {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	list := make([]string, 0, len(keys))
+	for _, k := range keys {
+		list = append(list, k+"="+escapeBaggageValue(o[k]))
+	}
+	return strings.Join(list, ",")
+}
+
+// limit
+// returns baggage within limits of configuration, members over limits
+// are discarded in order of key.
+func (o Baggage) limit() Baggage {
+	var (
+		c    = config.Config.GetBaggage()
+		keys = make([]string, 0, len(o))
+		n    = 0
+		x    = Baggage{}
+	)
+
+	for k := range o {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		size := len(k) + 1 + len(escapeBaggageValue(o[k]))
+		if len(x) > 0 {
+			size++
+		}
+		if len(x) >= c.GetMaxMembers() || n+size > c.GetMaxBytes() {
+			break
+		}
+		n += size
+		x[k] = o[k]
+	}
+	return x
+}
+
+// merge
+// returns a copy of ctx with baggage merged into baggage of ctx.
+func (o Baggage) merge(ctx context.Context) context.Context {
+	if len(o) == 0 {
+		return ctx
+	}
+
+	b := BaggageFromContext(ctx)
+	for k, v := range o {
+		b[k] = v
+	}
+	return context.WithValue(ctx, contextKeyBaggage, b.limit())
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// W3C baggage propagator
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *BaggagePropagator) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	b := Baggage{}
+	for _, m := range strings.Split(carrier.Get(headerBaggage), ",") {
+		// Properties
+		// after semicolon are not supported.
+		if i := strings.IndexByte(m, ';'); i >= 0 {
+			m = m[:i]
+		}
+
+		i := strings.IndexByte(m, '=')
+		if i <= 0 {
+			continue
+		}
+
+		k := strings.TrimSpace(m[:i])
+		v, err := url.PathUnescape(strings.TrimSpace(m[i+1:]))
+		if err != nil || !isBaggageKey(k) {
+			continue
+		}
+		b[k] = v
+	}
+	return b.merge(ctx)
+}
+
+func (o *BaggagePropagator) Fields() []string {
+	return []string{headerBaggage}
+}
+
+func (o *BaggagePropagator) Inject(ctx context.Context, carrier TextMapCarrier) {
+	if s := BaggageFromContext(ctx).limit().encode(); s != "" {
+		carrier.Set(headerBaggage, s)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Jaeger baggage propagator
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *JaegerBaggagePropagator) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	b := Baggage{}
+	for _, key := range carrier.Keys() {
+		if !strings.HasPrefix(strings.ToLower(key), headerJaegerBaggage) {
+			continue
+		}
+
+		k := strings.ToLower(key[len(headerJaegerBaggage):])
+		if v, err := url.QueryUnescape(carrier.Get(key)); err == nil && isBaggageKey(k) {
+			b[k] = v
+		}
+	}
+	return b.merge(ctx)
+}
+
+// Fields
+// returns nil, header names depend on keys of baggage.
+func (o *JaegerBaggagePropagator) Fields() []string { return nil }
+
+func (o *JaegerBaggagePropagator) Inject(ctx context.Context, carrier TextMapCarrier) {
+	for k, v := range BaggageFromContext(ctx).limit() {
+		carrier.Set(headerJaegerBaggage+k, url.QueryEscape(v))
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Access
+// /////////////////////////////////////////////////////////////////////////////
+
+// baggageAttr
+// returns values of keys in baggage of ctx, return nil if none found.
+func baggageAttr(ctx context.Context, keys []string) (attr Attr) {
+	if len(keys) == 0 {
+		return
+	}
+
+	b := BaggageFromContext(ctx)
+	for _, k := range keys {
+		if v, ok := b[k]; ok {
+			if attr == nil {
+				attr = Attr{}
+			}
+			attr.Add(k, v)
+		}
+	}
+	return
+}
+
+// escapeBaggageValue
+// returns value with bytes outside baggage-octet percent encoded.
+//
+//	baggage-octet = %x21 / %x23-2B / %x2D-3A / %x3C-5B / %x5D-7E
+func escapeBaggageValue(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == 0x21 || (c >= 0x23 && c <= 0x2b && c != 0x25) || (c >= 0x2d && c <= 0x3a) ||
+			(c >= 0x3c && c <= 0x5b) || (c >= 0x5d && c <= 0x7e) {
+			buf.WriteByte(c)
+			continue
+		}
+		buf.WriteByte('%')
+		buf.WriteByte(hex[c>>4])
+		buf.WriteByte(hex[c&0x0f])
+	}
+	return buf.String()
+}
+
+// isBaggageKey
+// return true if key is a token of RFC 7230.
+func isBaggageKey(k string) bool {
+	if k == "" {
+		return false
+	}
+	for i := 0; i < len(k); i++ {
+		c := k[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"context"
+	"github.com/fuyibing/log/config"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithBaggage(t *testing.T) {
+	ctx := WithBaggage(context.Background(), "tenant_id", "t1")
+	ctx = WithBaggage(ctx, "experiment", "new checkout")
+	ctx = WithBaggage(ctx, "bad key", "x")
+
+	b := BaggageFromContext(ctx)
+	if len(b) != 2 || b["tenant_id"] != "t1" || b["experiment"] != "new checkout" {
+		t.Fatalf("unexpected baggage: %v", b)
+	}
+
+	// Copy
+	// must not change baggage of context.
+	b["tenant_id"] = "t2"
+	if BaggageFromContext(ctx)["tenant_id"] != "t1" {
+		t.Errorf("baggage of context changed")
+	}
+
+	// Limits
+	// exceeded, member not added.
+	if x := WithBaggage(ctx, "large", strings.Repeat("x", config.DefaultBaggageMaxBytes)); x != ctx {
+		t.Errorf("expect member over limit discarded")
+	}
+}
+
+func TestBaggagePropagator(t *testing.T) {
+	p := &BaggagePropagator{}
+
+	ctx := p.Extract(context.Background(), MapCarrier{"baggage": "tenant_id=t1;prop=1, experiment=new%20checkout,bad key=x,empty"})
+	if b := BaggageFromContext(ctx); len(b) != 2 || b["experiment"] != "new checkout" {
+		t.Fatalf("unexpected baggage: %v", b)
+	}
+
+	out := MapCarrier{}
+	p.Inject(WithBaggage(ctx, "q", "a,b;c=d%"), out)
+	if s := out["baggage"]; s != "experiment=new%20checkout,q=a%2Cb%3Bc=d%25,tenant_id=t1" {
+		t.Errorf("unexpected baggage header: %s", s)
+	}
+}
+
+func TestJaegerBaggagePropagator(t *testing.T) {
+	p := &JaegerBaggagePropagator{}
+
+	header := http.Header{}
+	header.Set("uberctx-tenant_id", "t1")
+	header.Set("Uberctx-Experiment", "new+checkout")
+
+	b := BaggageFromContext(p.Extract(context.Background(), HeaderCarrier(header)))
+	if len(b) != 2 || b["tenant_id"] != "t1" || b["experiment"] != "new checkout" {
+		t.Fatalf("unexpected baggage: %v", b)
+	}
+
+	out := MapCarrier{}
+	p.Inject(WithBaggage(context.Background(), "experiment", "a b"), out)
+	if out["uberctx-experiment"] != "a+b" {
+		t.Errorf("unexpected injected headers: %v", out)
+	}
+}
+
+func TestBaggageCopy(t *testing.T) {
+	config.Config.With(config.BaggageSpanAttributes("tenant_id"), config.BaggageLogFields("tenant_id"))
+	defer config.Config.With(config.BaggageSpanAttributes(), config.BaggageLogFields())
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("baggage", "tenant_id=t1,experiment=e1")
+
+	tr := Provider.NewTraceWithRequest("request", req)
+	sp := tr.NewSpan("handler")
+	if sp.GetAttr()["tenant_id"] != "t1" {
+		t.Errorf("baggage not copied into span attributes: %v", sp.GetAttr())
+	}
+	if _, ok := sp.GetAttr()["experiment"]; ok {
+		t.Errorf("unselected baggage key copied")
+	}
+
+	// Child
+	// span with baggage added on context.
+	_, child := StartSpan(WithBaggage(sp.GetContext(), "tenant_id", "t2"), "child")
+	if child.GetAttr()["tenant_id"] != "t2" {
+		t.Errorf("expect baggage of context, got %v", child.GetAttr())
+	}
+
+	p := NewProvider()
+	e := &testLoggerExporter{}
+	p.AddLoggerExporter(e)
+	p.NewTraceWithContext(sp.GetContext(), "logs").NewSpan("log").Info("message")
+	if logs := e.logs; len(logs) != 1 || logs[0].Fields["tenant_id"] != "t1" {
+		t.Errorf("baggage not copied into log fields")
+	}
+}
@@ -0,0 +1,127 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"context"
+)
+
+type (
+	// contextKey
+	// unexported type of context keys, avoid collisions with keys
+	// defined in other packages.
+	contextKey int
+)
+
+const (
+	_ contextKey = iota
+	contextKeyBaggage
+	contextKeyProvider
+	contextKeyRemote
+	contextKeySpan
+	contextKeyTrace
+)
+
+// ContextWithProvider
+// returns a copy of ctx with provider stored, provider stores itself in
+// context passed to exporters started by it.
+func ContextWithProvider(ctx context.Context, p ProviderManager) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, contextKeyProvider, p)
+}
+
+// ContextWithSpan
+// returns a copy of ctx with span stored, spans created by StartSpan
+// with returned context are children of span.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, contextKeySpan, span)
+}
+
+// ProviderFromContext
+// returns the provider stored in ctx, return global Provider if not
+// stored.
+func ProviderFromContext(ctx context.Context) ProviderManager {
+	if ctx != nil {
+		if v, ok := ctx.Value(contextKeyProvider).(ProviderManager); ok {
+			return v
+		}
+	}
+	return Provider
+}
+
+// SpanFromContext
+// returns the span stored in ctx, return nil if not stored.
+func SpanFromContext(ctx context.Context) Span {
+	if ctx != nil {
+		if v, ok := ctx.Value(contextKeySpan).(Span); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// StartSpan
+// returns a span and a context with the span stored. Span is a child
+// of span in ctx, or of trace in ctx. A new trace is created on global
+// Provider if neither found.
+//
+//	ctx, span := tracer.StartSpan(ctx, "db.query")
+//	defer span.End()
+func StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var sp Span
+	if parent := SpanFromContext(ctx); parent != nil {
+		sp = parent.NewSpanWithContext(ctx, name)
+	} else if tr := TraceFromContext(ctx); tr != nil {
+		sp = tr.NewSpanWithContext(ctx, name)
+	} else {
+		tr = Provider.NewTraceWithContext(ctx, name)
+		sp = tr.NewSpanWithContext(tr.GetContext(), name)
+	}
+	return sp.GetContext(), sp
+}
+
+// TraceFromContext
+// returns the trace of span stored in ctx, or trace stored in ctx.
+// Return nil if neither stored.
+func TraceFromContext(ctx context.Context) Trace {
+	if sp := SpanFromContext(ctx); sp != nil {
+		return sp.GetTrace()
+	}
+	if ctx != nil {
+		if v, ok := ctx.Value(contextKeyTrace).(Trace); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// contextWithTrace
+// returns a copy of ctx with trace stored.
+func contextWithTrace(ctx context.Context, tr Trace) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, contextKeyTrace, tr)
+}
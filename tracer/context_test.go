@@ -0,0 +1,81 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStartSpan(t *testing.T) {
+	ctx, root := StartSpan(context.Background(), "root")
+	if SpanFromContext(ctx) != root {
+		t.Fatalf("span not stored in context")
+	}
+	if TraceFromContext(ctx) != root.GetTrace() {
+		t.Fatalf("trace not found from context")
+	}
+
+	ctx2, child := StartSpan(ctx, "child")
+	if child.GetParentSpanId() != root.GetSpanId() {
+		t.Errorf("child not parented to root")
+	}
+	if child.GetTraceId() != root.GetTraceId() {
+		t.Errorf("child in different trace")
+	}
+
+	_, grand := StartSpan(ctx2, "grand")
+	if grand.GetParentSpanId() != child.GetSpanId() {
+		t.Errorf("grand child not parented to child")
+	}
+
+	// Context of caller
+	// still refers to root.
+	if SpanFromContext(ctx) != root {
+		t.Errorf("parent context changed")
+	}
+}
+
+func TestStartSpanWithTrace(t *testing.T) {
+	tr := Provider.NewTrace("trace")
+
+	ctx, sp := StartSpan(tr.GetContext(), "span")
+	if sp.GetTrace() != tr {
+		t.Errorf("span not created on trace of context")
+	}
+	if !sp.GetParentSpanId().IsZero() {
+		t.Errorf("expect root span of trace")
+	}
+
+	child := tr.NewSpanWithContext(ctx, "child")
+	if child.GetParentSpanId() != sp.GetSpanId() {
+		t.Errorf("child not parented to span in context")
+	}
+
+	other := Provider.NewTrace("other").NewSpanWithContext(ctx, "other")
+	if other.GetParentSpanId() == sp.GetSpanId() {
+		t.Errorf("span of other trace must not be parent")
+	}
+}
+
+func TestContextWithoutSpan(t *testing.T) {
+	if SpanFromContext(context.Background()) != nil || TraceFromContext(context.Background()) != nil {
+		t.Errorf("expect nil from empty context")
+	}
+	if SpanFromContext(context.WithValue(context.Background(), ContextValueKey, "x")) != nil {
+		t.Errorf("string key must not collide")
+	}
+}
@@ -27,11 +27,6 @@ func traceCreator(ctx context.Context, provider *provider, name string) *trace {
 		provider: provider,
 	}
 
-	if ctx == nil {
-		tr.ctx = context.WithValue(context.Background(), ContextValueKey, tr)
-	} else {
-		tr.ctx = context.WithValue(ctx, ContextValueKey, tr)
-	}
-
+	tr.ctx = contextWithTrace(ctx, tr)
 	return tr
 }
@@ -17,9 +17,23 @@ package tracer
 
 import (
 	"context"
+	"github.com/fuyibing/log/config"
+	"sync"
+	"time"
+)
+
+const (
+	// errorReportInterval
+	// minimum interval between reports of errors of an exporter.
+	errorReportInterval = 10 * time.Second
 )
 
 type (
+	// ExporterOption
+	// filter and delivery option of an exporter registered by
+	// AddLoggerExporter or AddTracerExporter.
+	ExporterOption func(o *exporterFilter)
+
 	LoggerExporter interface {
 		Push(log *Log) error
 		Start(ctx context.Context) error
@@ -32,3 +46,115 @@ type (
 		Stopped() bool
 	}
 )
+
+type (
+	// errorLimiter
+	// limit reports of an exporter, the first error reported and
+	// others counted until report interval elapsed.
+	errorLimiter struct {
+		sync.Mutex
+
+		reported   time.Time
+		suppressed uint64
+	}
+
+	exporterFilter struct {
+		level      config.LoggerLevel
+		logFilter  func(log *Log) bool
+		spanFilter func(span Span) bool
+
+		// pushTimeout, spanQueue
+		// timeout of logger exporter in sync mode and capacity of
+		// tracer exporter queue, disabled if zero.
+		pushTimeout time.Duration
+		spanQueue   int
+	}
+)
+
+// WithLogFilter
+// push log to exporter only if predicate returns true.
+func WithLogFilter(fn func(log *Log) bool) ExporterOption {
+	return func(o *exporterFilter) { o.logFilter = fn }
+}
+
+// WithMinLevel
+// push log to exporter only if its level is as severe as or more
+// severe than specified level, such as WARN accepts WARN, ERROR and
+// FATAL. Used by logger exporter only.
+func WithMinLevel(level config.LoggerLevel) ExporterOption {
+	return func(o *exporterFilter) { o.level = level }
+}
+
+// WithPushTimeout
+// wait at most specified duration for a push of logger exporter in
+// sync mode. Exporter exceeded is skipped until its push returned,
+// logs skipped are counted as dropped and reported to stderr with
+// limited rate. Used by logger exporter only.
+//
+//	Provider.AddLoggerExporter(logger_kafka.New(), WithPushTimeout(time.Second))
+func WithPushTimeout(d time.Duration) ExporterOption {
+	return func(o *exporterFilter) { o.pushTimeout = d }
+}
+
+// WithSpanFilter
+// push span to exporter only if predicate returns true.
+func WithSpanFilter(fn func(span Span) bool) ExporterOption {
+	return func(o *exporterFilter) { o.spanFilter = fn }
+}
+
+// WithSpanQueue
+// push spans into a bounded queue after provider started, queue is
+// consumed by coroutine of exporter, a slow exporter does not block
+// others and span creators. Spans discarded if queue is full, counted
+// as dropped and reported as log with limited rate. Used by tracer
+// exporter only.
+//
+//	Provider.AddTracerExporter(tracer_jaeger.New(), WithSpanQueue(2048))
+func WithSpanQueue(capacity int) ExporterOption {
+	return func(o *exporterFilter) { o.spanQueue = capacity }
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Filter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *exporterFilter) acceptLog(log *Log) bool {
+	if o.level != "" {
+		i, c := log.Level.Int(), o.level.Int()
+		if i <= config.Off.Int() || c <= config.Off.Int() || i > c {
+			return false
+		}
+	}
+	return o.logFilter == nil || o.logFilter(log)
+}
+
+func (o *exporterFilter) acceptSpan(span Span) bool {
+	return o.spanFilter == nil || o.spanFilter(span)
+}
+
+func (o *exporterFilter) init(opts []ExporterOption) *exporterFilter {
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Error limiter: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// allow
+// returns true and count of suppressed errors since last report if
+// error should be reported.
+func (o *errorLimiter) allow() (suppressed uint64, ok bool) {
+	o.Lock()
+	defer o.Unlock()
+
+	if now := time.Now(); now.Sub(o.reported) >= errorReportInterval {
+		suppressed, o.suppressed, o.reported = o.suppressed, 0, now
+		return suppressed, true
+	}
+
+	o.suppressed++
+	return 0, false
+}
@@ -20,6 +20,12 @@ import (
 )
 
 const (
+	// ContextValueKey
+	// is not used any more, trace and span stored in context with
+	// unexported key.
+	//
+	// Deprecated: use ContextWithSpan, SpanFromContext and
+	// TraceFromContext.
 	ContextValueKey = "__LogTraceBound__"
 )
 
@@ -17,6 +17,9 @@ package tracer
 
 import (
 	"github.com/fuyibing/log/config"
+	"path/filepath"
+	"runtime"
+	"strconv"
 	"time"
 )
 
@@ -28,6 +31,26 @@ type (
 		Time  time.Time
 		Text  string
 		Type  LogType
+
+		// Attr
+		// attributes of provider which log sent by, such as
+		// service.name, assigned on push.
+		Attr Attr
+
+		// Caller
+		// file and line of caller, such as tracer/span.go:32,
+		// recorded if logger-caller enabled.
+		Caller string
+
+		// Fields
+		// structured key/value pairs of log.
+		Fields Attr
+
+		// SpanId, TraceId
+		// identities of span which log belongs to, zero value
+		// for internal log.
+		SpanId  SpanId
+		TraceId TraceId
 	}
 
 	LoggerManager interface {
@@ -50,3 +73,13 @@ func NewLog(t LogType, l config.LoggerLevel) *Log {
 		Type:  t,
 	}
 }
+
+// logCaller
+// returns file and line of caller, skip 0 means the caller of
+// logCaller.
+func logCaller(skip int) string {
+	if _, file, line, ok := runtime.Caller(skip + 1); ok {
+		return filepath.Base(filepath.Dir(file)) + "/" + filepath.Base(file) + ":" + strconv.Itoa(line)
+	}
+	return ""
+}
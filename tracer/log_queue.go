@@ -0,0 +1,193 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"github.com/fuyibing/log/config"
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// logQueue
+	// a bounded ring queue of logs used by async logger.
+	logQueue struct {
+		sync.Mutex
+		cond *sync.Cond
+
+		capacity   int
+		policy     config.LoggerAsyncPolicy
+		head, size int
+		items      []*Log
+		running    bool
+
+		dropped uint64
+	}
+)
+
+// Dropped
+// returns count of discarded logs.
+func (o *logQueue) Dropped() uint64 { return atomic.LoadUint64(&o.dropped) }
+
+// Queued
+// returns count of logs waiting in queue.
+func (o *logQueue) Queued() int {
+	o.Lock()
+	defer o.Unlock()
+	return o.size
+}
+
+// Push
+// log into queue, returns false if queue is not running, caller
+// should send it synchronously.
+func (o *logQueue) Push(log *Log) bool {
+	o.Lock()
+	defer o.Unlock()
+
+	for {
+		if !o.running {
+			return false
+		}
+
+		// Append
+		// if free space exists.
+		if o.size < o.capacity {
+			o.append(log)
+			return true
+		}
+
+		switch o.policy {
+		case config.AsyncDropNewest:
+			o.drop()
+			return true
+
+		case config.AsyncDropOldest:
+			o.shift()
+			o.drop()
+			o.append(log)
+			return true
+
+		case config.AsyncKeepError:
+			if !o.severe(log) {
+				o.drop()
+				return true
+			}
+			if o.evict() {
+				o.drop()
+				o.append(log)
+				return true
+			}
+		}
+
+		// Wait
+		// until consumer pop or queue stopped.
+		o.cond.Wait()
+	}
+}
+
+// Pop
+// returns the oldest log, block until a log pushed. Returns false
+// if queue is stopped and no log remained.
+func (o *logQueue) Pop() (log *Log, ok bool) {
+	o.Lock()
+	defer o.Unlock()
+
+	for o.size == 0 {
+		if !o.running {
+			return nil, false
+		}
+		o.cond.Wait()
+	}
+
+	log = o.shift()
+	o.cond.Broadcast()
+	return log, true
+}
+
+// Start
+// accept logs.
+func (o *logQueue) Start() {
+	o.Lock()
+	o.running = true
+	o.Unlock()
+}
+
+// Stop
+// refuse new logs, remained logs can be popped.
+func (o *logQueue) Stop() {
+	o.Lock()
+	o.running = false
+	o.cond.Broadcast()
+	o.Unlock()
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Queue: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *logQueue) append(log *Log) {
+	o.items[(o.head+o.size)%o.capacity] = log
+	o.size++
+	o.cond.Broadcast()
+}
+
+func (o *logQueue) drop() {
+	atomic.AddUint64(&o.dropped, 1)
+}
+
+// evict
+// remove the oldest log lower than ERROR.
+func (o *logQueue) evict() bool {
+	for i := 0; i < o.size; i++ {
+		if n := (o.head + i) % o.capacity; !o.severe(o.items[n]) {
+			// Move
+			// newer logs forward.
+			for j := i; j < o.size-1; j++ {
+				o.items[(o.head+j)%o.capacity] = o.items[(o.head+j+1)%o.capacity]
+			}
+			o.items[(o.head+o.size-1)%o.capacity] = nil
+			o.size--
+			return true
+		}
+	}
+	return false
+}
+
+func (o *logQueue) init(capacity int, policy config.LoggerAsyncPolicy) *logQueue {
+	if capacity <= 0 {
+		capacity = config.DefaultLoggerAsyncCapacity
+	}
+	o.capacity = capacity
+	o.cond = sync.NewCond(o)
+	o.items = make([]*Log, capacity)
+	o.policy = policy
+	return o
+}
+
+// severe
+// return true if level is ERROR or more severe.
+func (o *logQueue) severe(log *Log) bool {
+	i := log.Level.Int()
+	return i > config.Off.Int() && i <= config.Error.Int()
+}
+
+func (o *logQueue) shift() (log *Log) {
+	log = o.items[o.head]
+	o.items[o.head] = nil
+	o.head = (o.head + 1) % o.capacity
+	o.size--
+	return
+}
@@ -0,0 +1,77 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"github.com/fuyibing/log/config"
+	"testing"
+)
+
+func TestLogQueue_Policy(t *testing.T) {
+	for _, c := range []struct {
+		policy  config.LoggerAsyncPolicy
+		levels  []config.LoggerLevel
+		expect  []string
+		dropped uint64
+	}{
+		{config.AsyncDropNewest, []config.LoggerLevel{config.Info, config.Info, config.Info}, []string{"0", "1"}, 1},
+		{config.AsyncDropOldest, []config.LoggerLevel{config.Info, config.Info, config.Info}, []string{"1", "2"}, 1},
+		{config.AsyncKeepError, []config.LoggerLevel{config.Error, config.Info, config.Warn, config.Fatal}, []string{"0", "3"}, 2},
+	} {
+		q := (&logQueue{}).init(2, c.policy)
+		q.Start()
+
+		for i, level := range c.levels {
+			x := NewLog(LogInternal, level)
+			x.Text = string(rune('0' + i))
+			q.Push(x)
+		}
+		q.Stop()
+
+		got := make([]string, 0)
+		for {
+			x, ok := q.Pop()
+			if !ok {
+				break
+			}
+			got = append(got, x.Text)
+		}
+
+		if len(got) != len(c.expect) || got[0] != c.expect[0] || got[1] != c.expect[1] {
+			t.Errorf("policy %s: expect %v, got %v", c.policy, c.expect, got)
+		}
+		if q.Dropped() != c.dropped {
+			t.Errorf("policy %s: expect dropped %d, got %d", c.policy, c.dropped, q.Dropped())
+		}
+	}
+}
+
+func TestLogQueue_Stopped(t *testing.T) {
+	q := (&logQueue{}).init(1, config.AsyncBlock)
+	q.Start()
+	q.Push(NewLog(LogInternal, config.Info))
+
+	done := make(chan bool)
+	go func() { done <- q.Push(NewLog(LogInternal, config.Info)) }()
+
+	q.Stop()
+	if <-done {
+		t.Errorf("blocked push should be refused after stopped")
+	}
+	if q.Queued() != 1 {
+		t.Errorf("expect 1 queued log, got %d", q.Queued())
+	}
+}
@@ -0,0 +1,355 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"context"
+	"encoding/hex"
+	"github.com/fuyibing/log/config"
+	"github.com/valyala/fasthttp"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+type (
+	// Propagator
+	// read and write trace context on carrier across services.
+	Propagator interface {
+		// Extract
+		// returns a copy of ctx with remote SpanContext stored,
+		// return ctx if trace context not found or invalid.
+		Extract(ctx context.Context, carrier TextMapCarrier) context.Context
+
+		// Fields
+		// returns header names used by propagator.
+		Fields() []string
+
+		// Inject
+		// write trace context of span in ctx into carrier.
+		Inject(ctx context.Context, carrier TextMapCarrier)
+	}
+
+	// SpanContext
+	// trace context received from or sent to other service.
+	SpanContext struct {
+		TraceId TraceId
+		SpanId  SpanId
+
+		// ParentSpanId
+		// parent of SpanId, only carried by some formats.
+		ParentSpanId SpanId
+
+		// Sampled, Debug, Deferred
+		// sampling decision of upstream. Deferred means decision
+		// is not propagated, such as B3 without sample header.
+		Sampled  bool
+		Debug    bool
+		Deferred bool
+
+		// TraceState
+		// vendor specific data of W3C tracestate.
+		TraceState string
+	}
+
+	// TextMapCarrier
+	// storage of propagated key/value pairs, such as headers.
+	TextMapCarrier interface {
+		Get(key string) string
+		Keys() []string
+		Set(key, value string)
+	}
+
+	// FastHttpCarrier
+	// adapts fasthttp request header to TextMapCarrier.
+	//
+	//	tracer.Extract(ctx, tracer.FastHttpCarrier{Header: &c.Request.Header})
+	FastHttpCarrier struct {
+		Header *fasthttp.RequestHeader
+	}
+
+	// HeaderCarrier
+	// adapts http.Header to TextMapCarrier.
+	HeaderCarrier http.Header
+
+	// MapCarrier
+	// adapts map to TextMapCarrier, keys are used as is.
+	MapCarrier map[string]string
+
+	compositePropagator struct {
+		propagators []Propagator
+	}
+
+	// spanContextExtractor
+	// implemented by builtin propagators, composite propagator stop
+	// extracting SpanContext on first found.
+	spanContextExtractor interface {
+		extract(carrier TextMapCarrier) (SpanContext, bool)
+	}
+)
+
+var (
+	propagator      Propagator
+	propagatorMutex sync.RWMutex
+)
+
+// Extract
+// read trace context from carrier with GetPropagator().
+func Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	return GetPropagator().Extract(ctx, carrier)
+}
+
+// GetPropagator
+// returns propagator set by SetPropagator, or a composite propagator
+// of formats configured by open-tracing-format.
+func GetPropagator() Propagator {
+	propagatorMutex.RLock()
+	p := propagator
+	propagatorMutex.RUnlock()
+
+	if p != nil {
+		return p
+	}
+
+	list := make([]Propagator, 0)
+	for _, f := range config.Config.GetOpenTracingFormat() {
+		if x := PropagatorOf(f); x != nil {
+			list = append(list, x)
+		}
+	}
+	return NewCompositePropagator(list...)
+}
+
+// Inject
+// write trace context of span in ctx into carrier with GetPropagator().
+//
+//	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
+//	tracer.Inject(ctx, tracer.HeaderCarrier(req.Header))
+func Inject(ctx context.Context, carrier TextMapCarrier) {
+	GetPropagator().Inject(ctx, carrier)
+}
+
+// InjectHeader
+// write trace context of span in ctx into header of outgoing request.
+func InjectHeader(ctx context.Context, header http.Header) {
+	Inject(ctx, HeaderCarrier(header))
+}
+
+// NewCompositePropagator
+// returns a propagator of multiple formats. Extracted in order until
+// one found, injected in all formats.
+func NewCompositePropagator(propagators ...Propagator) Propagator {
+	return &compositePropagator{propagators: propagators}
+}
+
+// PropagatorOf
+// returns a builtin propagator of format, return nil if format is
+// not supported.
+func PropagatorOf(format config.PropagationFormat) Propagator {
+	switch format {
+	case config.PropagationBaggage:
+		return &BaggagePropagator{}
+	case config.PropagationB3:
+		return &B3Propagator{}
+	case config.PropagationB3Single:
+		return &B3SinglePropagator{}
+	case config.PropagationJaeger:
+		return &JaegerPropagator{}
+	case config.PropagationJaegerBaggage:
+		return &JaegerBaggagePropagator{}
+	case config.PropagationW3c:
+		return &W3cPropagator{}
+	}
+	return nil
+}
+
+// SetPropagator
+// replace global propagator, nil means formats in configuration.
+func SetPropagator(p Propagator) {
+	propagatorMutex.Lock()
+	propagator = p
+	propagatorMutex.Unlock()
+}
+
+// RemoteSpanContextFromContext
+// returns SpanContext extracted by propagator.
+func RemoteSpanContextFromContext(ctx context.Context) (sc SpanContext, ok bool) {
+	if ctx != nil {
+		sc, ok = ctx.Value(contextKeyRemote).(SpanContext)
+	}
+	return
+}
+
+// ContextWithRemoteSpanContext
+// returns a copy of ctx with remote SpanContext stored, traces created
+// with returned context continue the remote trace.
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, contextKeyRemote, sc)
+}
+
+// SpanContextFromContext
+// returns SpanContext to be injected. Span in ctx, trace with remote
+// parent in ctx, or remote SpanContext in ctx.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	if sp := SpanFromContext(ctx); sp != nil {
+		sc := spanContextOf(sp.GetTrace())
+		sc.ParentSpanId = sp.GetParentSpanId()
+		sc.SpanId = sp.GetSpanId()
+		return sc, true
+	}
+
+	if tr := TraceFromContext(ctx); tr != nil && !tr.GetSpanId().IsZero() {
+		return spanContextOf(tr), true
+	}
+
+	return RemoteSpanContextFromContext(ctx)
+}
+
+// spanContextOf
+// returns SpanContext of trace, span id is remote parent.
+func spanContextOf(tr Trace) SpanContext {
+	return SpanContext{
+		TraceId:    tr.GetTraceId(),
+		SpanId:     tr.GetSpanId(),
+		Sampled:    tr.GetSampled(),
+		Debug:      tr.GetDebug(),
+		TraceState: tr.GetTraceState(),
+	}
+}
+
+// hexSpanId
+// returns a SpanId of hex string, left-padded if leading zeros omitted.
+func hexSpanId(s string) (sid SpanId, ok bool) {
+	if n := len(s); n == 0 || n > 16 {
+		return
+	}
+	if len(s) < 16 {
+		s = strings.Repeat("0", 16-len(s)) + s
+	}
+	sid = Identify.HexSpanId(s)
+	return sid, sid.Err() == nil && !sid.IsZero()
+}
+
+// hexTraceId
+// returns a TraceId of hex string. 64-bit trace id, or trace id with
+// leading zeros omitted, is left-padded into 128-bit.
+func hexTraceId(s string) (tid TraceId, ok bool) {
+	if n := len(s); n == 0 || n > 32 {
+		return
+	}
+	if len(s) < 32 {
+		s = strings.Repeat("0", 32-len(s)) + s
+	}
+	tid = Identify.HexTraceId(s)
+	return tid, tid.Err() == nil && !tid.IsZero()
+}
+
+// shortTraceId
+// returns 16 hex of 64-bit trace id if high 64 bits are zero, for
+// compatibility of legacy services.
+func shortTraceId(tid TraceId) string {
+	if bs := tid.Byte(); bs[0]|bs[1]|bs[2]|bs[3]|bs[4]|bs[5]|bs[6]|bs[7] == 0 {
+		return hex.EncodeToString(bs[8:])
+	}
+	return hex.EncodeToString(tid.Byte())
+}
+
+// IsValid
+// return true if both trace id and span id are not zero.
+func (o SpanContext) IsValid() bool {
+	return !o.TraceId.IsZero() && !o.SpanId.IsZero()
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Carriers
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o FastHttpCarrier) Get(key string) string { return string(o.Header.Peek(key)) }
+func (o FastHttpCarrier) Set(key, value string) { o.Header.Set(key, value) }
+
+func (o FastHttpCarrier) Keys() []string {
+	keys := make([]string, 0)
+	o.Header.VisitAll(func(key, _ []byte) { keys = append(keys, string(key)) })
+	return keys
+}
+
+// Get
+// returns values of key joined by comma, such as multiple tracestate
+// headers.
+func (o HeaderCarrier) Get(key string) string {
+	return strings.Join(http.Header(o).Values(key), ",")
+}
+
+func (o HeaderCarrier) Set(key, value string) { http.Header(o).Set(key, value) }
+
+func (o HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(o))
+	for k := range o {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (o MapCarrier) Get(key string) string { return o[key] }
+func (o MapCarrier) Set(key, value string) { o[key] = value }
+
+func (o MapCarrier) Keys() []string {
+	keys := make([]string, 0, len(o))
+	for k := range o {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Composite propagator
+// /////////////////////////////////////////////////////////////////////////////
+
+// Extract
+// returns SpanContext of first propagator found, other propagators
+// such as baggage are always extracted.
+func (o *compositePropagator) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	found := false
+	for _, p := range o.propagators {
+		if e, ok := p.(spanContextExtractor); ok {
+			if !found {
+				if sc, ok := e.extract(carrier); ok {
+					ctx, found = ContextWithRemoteSpanContext(ctx, sc), true
+				}
+			}
+			continue
+		}
+		ctx = p.Extract(ctx, carrier)
+	}
+	return ctx
+}
+
+func (o *compositePropagator) Fields() []string {
+	list := make([]string, 0)
+	for _, p := range o.propagators {
+		list = append(list, p.Fields()...)
+	}
+	return list
+}
+
+func (o *compositePropagator) Inject(ctx context.Context, carrier TextMapCarrier) {
+	for _, p := range o.propagators {
+		p.Inject(ctx, carrier)
+	}
+}
@@ -0,0 +1,231 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"context"
+	"github.com/fuyibing/log/config"
+	"strings"
+)
+
+type (
+	// B3Propagator
+	// B3 multiple headers, header names default to open-tracing-trace-id,
+	// open-tracing-span-id and open-tracing-sample of configuration.
+	// Debug is sent as X-B3-Flags without sample header.
+	//
+	//	X-B3-TraceId: 80f198ee56343ba864fe8b2a57d3eff7
+	//	X-B3-SpanId: e457b5a2e4d86bd1
+	//	X-B3-Sampled: 1
+	//	X-B3-Flags: 1
+	B3Propagator struct {
+		FlagsHeader, SampleHeader, SpanIdHeader, TraceIdHeader string
+	}
+
+	// B3SinglePropagator
+	// B3 single header, sampling state and parent span id are optional.
+	//
+	//	b3: 80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1-05e3ac9a4f6e3b90
+	B3SinglePropagator struct{}
+)
+
+const (
+	headerB3      = "b3"
+	headerB3Flags = "X-B3-Flags"
+)
+
+func (o *B3Propagator) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	if sc, ok := o.extract(carrier); ok {
+		return ContextWithRemoteSpanContext(ctx, sc)
+	}
+	return ctx
+}
+
+func (o *B3Propagator) Fields() []string {
+	return []string{o.traceIdHeader(), o.spanIdHeader(), o.sampleHeader(), o.flagsHeader()}
+}
+
+func (o *B3Propagator) Inject(ctx context.Context, carrier TextMapCarrier) {
+	sc, ok := SpanContextFromContext(ctx)
+	if !ok || !sc.IsValid() {
+		return
+	}
+
+	carrier.Set(o.traceIdHeader(), shortTraceId(sc.TraceId))
+	carrier.Set(o.spanIdHeader(), sc.SpanId.String())
+
+	// Debug
+	// implies sampled, sample header is not sent.
+	switch {
+	case sc.Debug:
+		carrier.Set(o.flagsHeader(), "1")
+	case sc.Sampled:
+		carrier.Set(o.sampleHeader(), "1")
+	default:
+		carrier.Set(o.sampleHeader(), "0")
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// B3 propagator: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *B3Propagator) extract(carrier TextMapCarrier) (sc SpanContext, ok bool) {
+	var (
+		sid = carrier.Get(o.spanIdHeader())
+		tid = carrier.Get(o.traceIdHeader())
+	)
+
+	if tid == "" || sid == "" {
+		return
+	}
+
+	if sc.SpanId, ok = hexSpanId(sid); !ok {
+		return
+	}
+	if sc.TraceId, ok = hexTraceId(tid); !ok {
+		return
+	}
+
+	// Debug
+	// flag of upstream, sample header ignored.
+	if carrier.Get(o.flagsHeader()) == "1" {
+		sc.Debug, sc.Sampled = true, true
+		return
+	}
+
+	// Sample
+	// decision of upstream, deferred if header not sent.
+	switch strings.ToLower(carrier.Get(o.sampleHeader())) {
+	case "1", "true":
+		sc.Sampled = true
+	case "0", "false":
+	default:
+		sc.Deferred = true
+	}
+	return
+}
+
+func (o *B3Propagator) flagsHeader() string {
+	if o.FlagsHeader != "" {
+		return o.FlagsHeader
+	}
+	return headerB3Flags
+}
+
+func (o *B3Propagator) sampleHeader() string {
+	if o.SampleHeader != "" {
+		return o.SampleHeader
+	}
+	return config.Config.GetOpenTracingSample()
+}
+
+func (o *B3Propagator) spanIdHeader() string {
+	if o.SpanIdHeader != "" {
+		return o.SpanIdHeader
+	}
+	return config.Config.GetOpenTracingSpanId()
+}
+
+func (o *B3Propagator) traceIdHeader() string {
+	if o.TraceIdHeader != "" {
+		return o.TraceIdHeader
+	}
+	return config.Config.GetOpenTracingTraceId()
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// B3 single propagator
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *B3SinglePropagator) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	if sc, ok := o.extract(carrier); ok {
+		return ContextWithRemoteSpanContext(ctx, sc)
+	}
+	return ctx
+}
+
+func (o *B3SinglePropagator) Fields() []string {
+	return []string{headerB3}
+}
+
+func (o *B3SinglePropagator) Inject(ctx context.Context, carrier TextMapCarrier) {
+	sc, ok := SpanContextFromContext(ctx)
+	if !ok || !sc.IsValid() {
+		return
+	}
+
+	s := shortTraceId(sc.TraceId) + "-" + sc.SpanId.String()
+	switch {
+	case sc.Debug:
+		s += "-d"
+	case sc.Sampled:
+		s += "-1"
+	default:
+		s += "-0"
+	}
+	if !sc.ParentSpanId.IsZero() {
+		s += "-" + sc.ParentSpanId.String()
+	}
+	carrier.Set(headerB3, s)
+}
+
+// extract
+// returns SpanContext of b3 header. Header with sampling state only,
+// such as "b3: 0", has no ids and is ignored.
+func (o *B3SinglePropagator) extract(carrier TextMapCarrier) (sc SpanContext, ok bool) {
+	parts := strings.Split(strings.TrimSpace(carrier.Get(headerB3)), "-")
+	if len(parts) < 2 || len(parts) > 4 {
+		return
+	}
+
+	if len(parts[0]) != 16 && len(parts[0]) != 32 {
+		return
+	}
+	if sc.TraceId, ok = hexTraceId(parts[0]); !ok {
+		return
+	}
+	if len(parts[1]) != 16 {
+		return sc, false
+	}
+	if sc.SpanId, ok = hexSpanId(parts[1]); !ok {
+		return
+	}
+
+	sc.Deferred = true
+	if len(parts) > 2 {
+		switch parts[2] {
+		case "1":
+			sc.Deferred, sc.Sampled = false, true
+		case "0":
+			sc.Deferred = false
+		case "d":
+			sc.Deferred, sc.Debug, sc.Sampled = false, true, true
+		default:
+			return sc, false
+		}
+	}
+
+	if len(parts) > 3 {
+		if len(parts[3]) != 16 {
+			return sc, false
+		}
+		if sc.ParentSpanId, ok = hexSpanId(parts[3]); !ok {
+			return
+		}
+	}
+	return sc, true
+}
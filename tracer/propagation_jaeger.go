@@ -0,0 +1,119 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type (
+	// JaegerPropagator
+	// jaeger uber-trace-id header, parent span id is deprecated and
+	// sent as 0.
+	//
+	//	uber-trace-id: {trace-id}:{span-id}:{parent-span-id}:{flags}
+	JaegerPropagator struct {
+		// Header
+		// header name, default uber-trace-id.
+		Header string
+	}
+)
+
+const (
+	headerJaeger = "uber-trace-id"
+
+	jaegerFlagSampled = 0x01
+	jaegerFlagDebug   = 0x02
+)
+
+func (o *JaegerPropagator) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	if sc, ok := o.extract(carrier); ok {
+		return ContextWithRemoteSpanContext(ctx, sc)
+	}
+	return ctx
+}
+
+func (o *JaegerPropagator) Fields() []string {
+	return []string{o.header()}
+}
+
+func (o *JaegerPropagator) Inject(ctx context.Context, carrier TextMapCarrier) {
+	sc, ok := SpanContextFromContext(ctx)
+	if !ok || !sc.IsValid() {
+		return
+	}
+
+	flags := 0
+	if sc.Sampled || sc.Debug {
+		flags |= jaegerFlagSampled
+	}
+	if sc.Debug {
+		flags |= jaegerFlagDebug
+	}
+
+	carrier.Set(o.header(), shortTraceId(sc.TraceId)+":"+sc.SpanId.String()+":0:"+strconv.FormatInt(int64(flags), 16))
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Jaeger propagator: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// extract
+// returns SpanContext of header, value may be url encoded and ids may
+// omit leading zeros.
+func (o *JaegerPropagator) extract(carrier TextMapCarrier) (sc SpanContext, ok bool) {
+	s := carrier.Get(o.header())
+	if strings.Contains(s, "%") {
+		if x, err := url.QueryUnescape(s); err == nil {
+			s = x
+		}
+	}
+
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 4 {
+		return
+	}
+
+	if sc.TraceId, ok = hexTraceId(parts[0]); !ok {
+		return
+	}
+	if sc.SpanId, ok = hexSpanId(parts[1]); !ok {
+		return
+	}
+	if parts[2] != "0" && parts[2] != "" {
+		if sc.ParentSpanId, ok = hexSpanId(parts[2]); !ok {
+			return
+		}
+	}
+
+	flags, err := strconv.ParseUint(parts[3], 16, 8)
+	if err != nil {
+		return sc, false
+	}
+	sc.Debug = flags&jaegerFlagDebug != 0
+	sc.Sampled = flags&jaegerFlagSampled != 0 || sc.Debug
+	return sc, true
+}
+
+func (o *JaegerPropagator) header() string {
+	if o.Header != "" {
+		return o.Header
+	}
+	return headerJaeger
+}
@@ -0,0 +1,306 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"context"
+	"github.com/fuyibing/log/config"
+	"github.com/valyala/fasthttp"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	for s, expect := range map[string]bool{
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01":      true,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00":      true,
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-what": true,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-what": false,
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01what":  false,
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01":      false,
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01":      false,
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01":      false,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01":      false,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7":         false,
+		"": false,
+	} {
+		if _, _, _, ok := parseTraceparent(s); ok != expect {
+			t.Errorf("parse %q: expect %v, got %v", s, expect, ok)
+		}
+	}
+
+	tid, sid, sampled, _ := parseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-03")
+	if tid.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sid.String() != "00f067aa0ba902b7" || !sampled {
+		t.Errorf("unexpected result: %s %s %v", tid, sid, sampled)
+	}
+}
+
+func TestParseTracestate(t *testing.T) {
+	if s, ok := parseTracestate("rojo=00f067aa0ba902b7, congo=t61rcWkgMzE,tenant@vendor=v"); !ok || s != "rojo=00f067aa0ba902b7,congo=t61rcWkgMzE,tenant@vendor=v" {
+		t.Errorf("unexpected tracestate: %q %v", s, ok)
+	}
+
+	for _, s := range []string{"a=1,a=2", "Upper=1", "k=v,=x", "k=a,b", "k=v=x"} {
+		if _, ok := parseTracestate(s); ok {
+			t.Errorf("expect %q invalid", s)
+		}
+	}
+}
+
+func TestTraceExtractW3c(t *testing.T) {
+	config.Config.With(config.OpenTracingFormat(config.PropagationB3, config.PropagationW3c))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	req.Header.Add("tracestate", "rojo=00f067aa0ba902b7")
+	req.Header.Add("tracestate", "congo=t61rcWkgMzE")
+
+	tr := Provider.NewTraceWithRequest("request", req)
+	if tr.GetTraceId().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || tr.GetSpanId().String() != "00f067aa0ba902b7" {
+		t.Fatalf("trace context not extracted: %s %s", tr.GetTraceId(), tr.GetSpanId())
+	}
+	if tr.GetSampled() {
+		t.Errorf("expect not sampled")
+	}
+
+	// Inject
+	// into outgoing request.
+	sp := tr.NewSpan("call")
+	header := http.Header{}
+	InjectHeader(sp.GetContext(), header)
+
+	if s := header.Get("traceparent"); s != "00-4bf92f3577b34da6a3ce929d0e0e4736-"+sp.GetSpanId().String()+"-00" {
+		t.Errorf("unexpected traceparent: %s", s)
+	}
+	if s := header.Get("tracestate"); s != "rojo=00f067aa0ba902b7,congo=t61rcWkgMzE" {
+		t.Errorf("unexpected tracestate: %s", s)
+	}
+	if s := header.Get(config.Config.GetOpenTracingTraceId()); s != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("unexpected b3 trace id: %s", s)
+	}
+}
+
+func TestTraceExtractOrder(t *testing.T) {
+	defer config.Config.With(config.OpenTracingFormat(config.PropagationB3, config.PropagationW3c))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(config.Config.GetOpenTracingTraceId(), "11111111111111111111111111111111")
+	req.Header.Set(config.Config.GetOpenTracingSpanId(), "2222222222222222")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	if tr := Provider.NewTraceWithRequest("b3", req); tr.GetTraceId().String() != "11111111111111111111111111111111" {
+		t.Errorf("expect b3 extracted first, got %s", tr.GetTraceId())
+	}
+
+	config.Config.With(config.OpenTracingFormat(config.PropagationW3c))
+	if tr := Provider.NewTraceWithRequest("w3c", req); tr.GetTraceId().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expect w3c only, got %s", tr.GetTraceId())
+	}
+
+	// Root
+	// trace if no context found.
+	tr := Provider.NewTraceWithRequest("root", httptest.NewRequest(http.MethodGet, "/", nil))
+	if tr.GetTraceId().IsZero() || !tr.GetSampled() {
+		t.Errorf("expect a sampled root trace")
+	}
+}
+
+func TestB3PropagatorSample(t *testing.T) {
+	p := &B3Propagator{}
+	for value, expect := range map[string][3]bool{
+		"1": {true, false, false},
+		"0": {false, false, false},
+		"d": {false, false, true},
+		"":  {false, false, true},
+	} {
+		carrier := MapCarrier{
+			config.Config.GetOpenTracingTraceId(): "4bf92f3577b34da6a3ce929d0e0e4736",
+			config.Config.GetOpenTracingSpanId():  "00f067aa0ba902b7",
+		}
+		if value != "" {
+			carrier[config.Config.GetOpenTracingSample()] = value
+		}
+
+		sc, ok := RemoteSpanContextFromContext(p.Extract(context.Background(), carrier))
+		if !ok {
+			t.Fatalf("sample %q: not extracted", value)
+		}
+		if got := [3]bool{sc.Sampled, sc.Debug, sc.Deferred}; got != expect {
+			t.Errorf("sample %q: expect %v, got %v", value, expect, got)
+		}
+	}
+
+	// Unsampled
+	// trace injects sample header 0.
+	carrier := MapCarrier{
+		config.Config.GetOpenTracingTraceId(): "4bf92f3577b34da6a3ce929d0e0e4736",
+		config.Config.GetOpenTracingSpanId():  "00f067aa0ba902b7",
+		config.Config.GetOpenTracingSample():  "0",
+	}
+	tr := Provider.NewTraceWithContext(p.Extract(context.Background(), carrier), "unsampled")
+	if tr.GetSampled() {
+		t.Fatalf("expect unsampled trace")
+	}
+
+	out := MapCarrier{}
+	p.Inject(tr.NewSpan("call").GetContext(), out)
+	if out[config.Config.GetOpenTracingSample()] != "0" || out[config.Config.GetOpenTracingTraceId()] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("unexpected injected headers: %v", out)
+	}
+}
+
+func TestCompositePropagator(t *testing.T) {
+	p := NewCompositePropagator(&W3cPropagator{}, &B3Propagator{})
+
+	carrier := MapCarrier{
+		"traceparent":                         "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		config.Config.GetOpenTracingTraceId(): "11111111111111111111111111111111",
+		config.Config.GetOpenTracingSpanId():  "2222222222222222",
+	}
+	sc, ok := RemoteSpanContextFromContext(p.Extract(context.Background(), carrier))
+	if !ok || sc.TraceId.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expect first format extracted, got %v", sc.TraceId)
+	}
+
+	delete(carrier, "traceparent")
+	if sc, _ = RemoteSpanContextFromContext(p.Extract(context.Background(), carrier)); sc.TraceId.String() != "11111111111111111111111111111111" {
+		t.Errorf("expect second format extracted, got %v", sc.TraceId)
+	}
+
+	if len(p.Fields()) != 6 {
+		t.Errorf("unexpected fields: %v", p.Fields())
+	}
+}
+
+func TestFastHttpCarrier(t *testing.T) {
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+
+	_, sp := StartSpan(context.Background(), "call")
+	Inject(sp.GetContext(), FastHttpCarrier{Header: &req.Header})
+
+	ctx := Extract(context.Background(), FastHttpCarrier{Header: &req.Header})
+	tr := Provider.NewTraceWithContext(ctx, "server")
+	if tr.GetTraceId().String() != sp.GetTraceId().String() || tr.GetSpanId().String() != sp.GetSpanId().String() {
+		t.Errorf("trace context not propagated over fasthttp header")
+	}
+	if len(FastHttpCarrier{Header: &req.Header}.Keys()) == 0 {
+		t.Errorf("expect keys of fasthttp header")
+	}
+}
+
+func TestJaegerPropagator(t *testing.T) {
+	p := &JaegerPropagator{}
+
+	for value, expect := range map[string]string{
+		"4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1": "4bf92f3577b34da6a3ce929d0e0e4736",
+		"a3ce929d0e0e4736:f067aa0ba902b7:0:3":                   "0000000000000000a3ce929d0e0e4736",
+		"a3ce929d0e0e4736%3A00f067aa0ba902b7%3A0%3A1":           "0000000000000000a3ce929d0e0e4736",
+		"a3ce929d0e0e4736:00f067aa0ba902b7:0":                   "",
+		"a3ce929d0e0e4736:0:0:1":                                "",
+		"a3ce929d0e0e4736:00f067aa0ba902b7:0:zz":                "",
+	} {
+		sc, ok := p.extract(MapCarrier{"uber-trace-id": value})
+		if ok != (expect != "") || (ok && sc.TraceId.String() != expect) {
+			t.Errorf("extract %q: expect %q, got %q %v", value, expect, sc.TraceId, ok)
+		}
+	}
+
+	// Debug
+	// flag implies sampled and is propagated.
+	ctx := p.Extract(context.Background(), MapCarrier{"uber-trace-id": "a3ce929d0e0e4736:f067aa0ba902b7:0:2"})
+	sp := Provider.NewTraceWithContext(ctx, "debug").NewSpan("call")
+	if !sp.GetTrace().GetSampled() {
+		t.Errorf("expect debug trace sampled")
+	}
+
+	out := MapCarrier{}
+	p.Inject(sp.GetContext(), out)
+	if s := out["uber-trace-id"]; s != "a3ce929d0e0e4736:"+sp.GetSpanId().String()+":0:3" {
+		t.Errorf("unexpected injected header: %s", s)
+	}
+}
+
+func TestB3PropagatorDebug(t *testing.T) {
+	p := &B3Propagator{}
+
+	// Extract
+	// debug from X-B3-Flags only.
+	for flags, debug := range map[string]bool{"1": true, "0": false, "": false} {
+		carrier := MapCarrier{
+			config.Config.GetOpenTracingTraceId(): "4bf92f3577b34da6a3ce929d0e0e4736",
+			config.Config.GetOpenTracingSpanId():  "00f067aa0ba902b7",
+			config.Config.GetOpenTracingSample():  "0",
+		}
+		if flags != "" {
+			carrier["X-B3-Flags"] = flags
+		}
+
+		sc, ok := RemoteSpanContextFromContext(p.Extract(context.Background(), carrier))
+		if !ok || sc.Debug != debug || sc.Sampled != debug {
+			t.Errorf("flags %q: expect debug %v, got %+v", flags, debug, sc)
+		}
+	}
+
+	// Inject
+	// debug as X-B3-Flags without sample header.
+	carrier := MapCarrier{
+		config.Config.GetOpenTracingTraceId(): "4bf92f3577b34da6a3ce929d0e0e4736",
+		config.Config.GetOpenTracingSpanId():  "00f067aa0ba902b7",
+		"X-B3-Flags":                          "1",
+	}
+	tr := Provider.NewTraceWithContext(p.Extract(context.Background(), carrier), "debug")
+
+	out := MapCarrier{}
+	p.Inject(tr.NewSpan("call").GetContext(), out)
+	if _, ok := out[config.Config.GetOpenTracingSample()]; ok || out["X-B3-Flags"] != "1" {
+		t.Errorf("unexpected injected headers: %v", out)
+	}
+}
+
+func TestB3SinglePropagator(t *testing.T) {
+	p := &B3SinglePropagator{}
+
+	for value, expect := range map[string][3]bool{
+		"80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1-05e3ac9a4f6e3b90": {true, true, false},
+		"64fe8b2a57d3eff7-e457b5a2e4d86bd1-d":                                  {true, true, true},
+		"64fe8b2a57d3eff7-e457b5a2e4d86bd1-0":                                  {true, false, false},
+		"64fe8b2a57d3eff7-e457b5a2e4d86bd1":                                    {true, false, false},
+		"64fe8b2a57d3eff7-e457b5a2e4d86bd1-x":                                  {false},
+		"64fe8b2a57d3eff-e457b5a2e4d86bd1-1":                                   {false},
+		"0":                                                                    {false},
+	} {
+		sc, ok := p.extract(MapCarrier{"b3": value})
+		if got := [3]bool{ok, ok && sc.Sampled, ok && sc.Debug}; got != expect {
+			t.Errorf("extract %q: expect %v, got %v", value, expect, got)
+		}
+	}
+
+	sc, _ := p.extract(MapCarrier{"b3": "64fe8b2a57d3eff7-e457b5a2e4d86bd1"})
+	if !sc.Deferred || sc.TraceId.String() != "000000000000000064fe8b2a57d3eff7" {
+		t.Errorf("expect deferred and padded trace id, got %v %s", sc.Deferred, sc.TraceId)
+	}
+
+	ctx := p.Extract(context.Background(), MapCarrier{"b3": "64fe8b2a57d3eff7-e457b5a2e4d86bd1-1"})
+	_, sp := StartSpan(Provider.NewTraceWithContext(ctx, "server").GetContext(), "call")
+
+	out := MapCarrier{}
+	p.Inject(sp.GetContext(), out)
+	if s := out["b3"]; s != "64fe8b2a57d3eff7-"+sp.GetSpanId().String()+"-1-e457b5a2e4d86bd1" {
+		t.Errorf("unexpected injected header: %s", s)
+	}
+}
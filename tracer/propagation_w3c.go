@@ -0,0 +1,228 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+)
+
+type (
+	// W3cPropagator
+	// W3C trace context, traceparent and tracestate headers.
+	//
+	//	traceparent: 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+	//	tracestate: rojo=00f067aa0ba902b7
+	W3cPropagator struct{}
+)
+
+const (
+	headerTraceparent = "traceparent"
+	headerTracestate  = "tracestate"
+
+	// traceparentLength
+	// length of version 00, higher versions may append fields
+	// separated by dash.
+	traceparentLength = 55
+
+	// tracestateMembers
+	// maximum list members of tracestate.
+	tracestateMembers = 32
+
+	traceFlagSampled = 0x01
+)
+
+func (o *W3cPropagator) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	if sc, ok := o.extract(carrier); ok {
+		return ContextWithRemoteSpanContext(ctx, sc)
+	}
+	return ctx
+}
+
+func (o *W3cPropagator) Fields() []string {
+	return []string{headerTraceparent, headerTracestate}
+}
+
+func (o *W3cPropagator) Inject(ctx context.Context, carrier TextMapCarrier) {
+	sc, ok := SpanContextFromContext(ctx)
+	if !ok || !sc.IsValid() {
+		return
+	}
+
+	carrier.Set(headerTraceparent, formatTraceparent(sc.TraceId, sc.SpanId, sc.Sampled || sc.Debug))
+	if sc.TraceState != "" {
+		carrier.Set(headerTracestate, sc.TraceState)
+	}
+}
+
+func (o *W3cPropagator) extract(carrier TextMapCarrier) (sc SpanContext, ok bool) {
+	if sc.TraceId, sc.SpanId, sc.Sampled, ok = parseTraceparent(carrier.Get(headerTraceparent)); !ok {
+		return
+	}
+
+	// Tracestate
+	// discarded if invalid, traceparent is still used. Values of
+	// multiple headers are joined by carrier.
+	if s := carrier.Get(headerTracestate); s != "" {
+		sc.TraceState, _ = parseTracestate(s)
+	}
+	return
+}
+
+// formatTraceparent
+// returns traceparent header value of version 00.
+func formatTraceparent(tid TraceId, sid SpanId, sampled bool) string {
+	flags := "00"
+	if sampled {
+		flags = "01"
+	}
+	return "00-" + hex.EncodeToString(tid.Byte()) + "-" + hex.EncodeToString(sid.Byte()) + "-" + flags
+}
+
+// parseTraceparent
+// returns ids and sampled flag of traceparent header.
+//
+//	00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func parseTraceparent(s string) (tid TraceId, sid SpanId, sampled bool, ok bool) {
+	s = strings.TrimSpace(s)
+	if len(s) < traceparentLength || s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return
+	}
+
+	// Version
+	// ff is invalid, 00 must not have extra fields, future versions
+	// parsed as 00 if extra fields separated by dash.
+	version := s[0:2]
+	if !isLowerHex(version) || version == "ff" {
+		return
+	}
+	if version == "00" && len(s) != traceparentLength {
+		return
+	}
+	if len(s) > traceparentLength && s[traceparentLength] != '-' {
+		return
+	}
+
+	var (
+		traceHex = s[3:35]
+		spanHex  = s[36:52]
+		flagHex  = s[53:55]
+	)
+	if !isLowerHex(traceHex) || !isLowerHex(spanHex) || !isLowerHex(flagHex) {
+		return
+	}
+
+	if tid = Identify.HexTraceId(traceHex); tid.Err() != nil || tid.IsZero() {
+		return
+	}
+	if sid = Identify.HexSpanId(spanHex); sid.Err() != nil || sid.IsZero() {
+		return
+	}
+
+	flags, _ := hex.DecodeString(flagHex)
+	return tid, sid, flags[0]&traceFlagSampled != 0, true
+}
+
+// parseTracestate
+// returns normalized tracestate of header value. Return false if any
+// member is invalid.
+func parseTracestate(value string) (string, bool) {
+	var (
+		keys    = make(map[string]bool)
+		members = make([]string, 0)
+	)
+
+	for _, m := range strings.Split(value, ",") {
+		if m = strings.TrimSpace(m); m == "" {
+			continue
+		}
+
+		i := strings.IndexByte(m, '=')
+		if i <= 0 {
+			return "", false
+		}
+
+		k, v := m[:i], m[i+1:]
+		if !isTracestateKey(k) || !isTracestateValue(v) || keys[k] {
+			return "", false
+		}
+
+		keys[k] = true
+		members = append(members, m)
+	}
+
+	if len(members) > tracestateMembers {
+		return "", false
+	}
+	return strings.Join(members, ","), true
+}
+
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// isTracestateKey
+// return true if key is simple-key or multi-tenant-key.
+//
+//	simple-key = lcalpha 0*255( lcalpha / DIGIT / "_" / "-"/ "*" / "/" )
+//	tenant-id  = ( lcalpha / DIGIT ) 0*240( lcalpha / DIGIT / "_" / "-"/ "*" / "/" )
+//	system-id  = lcalpha 0*13( lcalpha / DIGIT / "_" / "-"/ "*" / "/" )
+func isTracestateKey(k string) bool {
+	if i := strings.IndexByte(k, '@'); i >= 0 {
+		tenant, system := k[:i], k[i+1:]
+		return len(tenant) > 0 && len(tenant) <= 241 && isTracestateKeyChars(tenant, true) &&
+			len(system) > 0 && len(system) <= 14 && isTracestateKeyChars(system, false)
+	}
+	return len(k) > 0 && len(k) <= 256 && isTracestateKeyChars(k, false)
+}
+
+func isTracestateKeyChars(s string, digitFirst bool) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z':
+		case c >= '0' && c <= '9':
+			if i == 0 && !digitFirst {
+				return false
+			}
+		case i > 0 && (c == '_' || c == '-' || c == '*' || c == '/'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isTracestateValue
+// return true if value is printable ascii without comma and equal,
+// and not end with space.
+func isTracestateValue(v string) bool {
+	if len(v) == 0 || len(v) > 256 || v[len(v)-1] == ' ' {
+		return false
+	}
+	for i := 0; i < len(v); i++ {
+		if c := v[i]; c < 0x20 || c > 0x7e || c == ',' || c == '=' {
+			return false
+		}
+	}
+	return true
+}
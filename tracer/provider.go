@@ -25,9 +25,16 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+const (
+	pushRunning int32 = iota
+	pushFinished
+	pushStalled
+)
+
 var (
 	// Provider
 	// singleton instance for global provider manager..
@@ -49,17 +56,46 @@ type (
 		attr    Attr
 		cancel  context.CancelFunc
 		ctx     context.Context
+		sampler Sampler
 		started bool
 
-		loggerExporter        LoggerExporter
-		loggerExporterEnabled bool
+		loggerExporters []*loggerExporterEntry
+		tracerExporters []*tracerExporterEntry
+	}
+
+	// loggerExporterEntry
+	// a registered logger exporter, entry is immutable and replaced
+	// on change.
+	loggerExporterEntry struct {
+		errors   *errorLimiter
+		exporter LoggerExporter
+		filter   *exporterFilter
+		queue    *logQueue
+
+		// dropped, stalled
+		// count of logs skipped in sync mode and count of running
+		// pushes exceeded push timeout, accessed atomically.
+		dropped uint64
+		stalled int32
+	}
 
-		tracerExporter        TracerExporter
-		tracerExporterEnabled bool
+	// tracerExporterEntry
+	// a registered tracer exporter, spans pushed into queue consumed
+	// by coroutine of exporter if span queue enabled and provider
+	// started.
+	tracerExporterEntry struct {
+		errors   *errorLimiter
+		exporter TracerExporter
+		filter   *exporterFilter
+		queue    *spanQueue
 	}
 
 	providerGetter interface {
 		GetAttr() Attr
+		GetLoggerDropped() uint64
+		GetLoggerQueued() int
+		GetSampler() Sampler
+		GetTracerDropped() uint64
 		NewTrace(name string) Trace
 		NewTraceWithContext(ctx context.Context, name string) Trace
 		NewTraceWithRequest(name string, request *http.Request) Trace
@@ -67,23 +103,80 @@ type (
 
 	providerPusher interface {
 		PushBaseLog(level config.LoggerLevel, text string, args ...interface{})
+		PushBaseLogWith(level config.LoggerLevel, fields Attr, text string, args ...interface{})
+		PushLog(log *Log)
 		PushSpan(span Span)
 		PushSpanLog(level config.LoggerLevel, text string, args ...interface{})
 	}
 
 	providerSetter interface {
+		AddLoggerExporter(exporter LoggerExporter, opts ...ExporterOption)
+		AddTracerExporter(exporter TracerExporter, opts ...ExporterOption)
 		SetAttr(key string, value interface{}) ProviderManager
 		SetLoggerExporter(logger LoggerExporter)
+		SetSampler(sampler Sampler)
 		SetTracerExporter(exporter TracerExporter)
 		Start(ctx context.Context) error
 		Stop() bool
 	}
 )
 
+// NewProvider
+// returns an isolated provider, traces created by it push spans and
+// logs to its own exporters. Used in tests, package functions such as
+// log.Info() use global Provider.
+func NewProvider() ProviderManager {
+	return (&provider{}).init()
+}
+
 // GetAttr
 // returns an attribute fields.
 func (o *provider) GetAttr() Attr { return o.attr }
 
+// GetLoggerDropped
+// returns count of logs discarded by async queues of all logger
+// exporters, or skipped as exporter stalled in sync mode.
+func (o *provider) GetLoggerDropped() (n uint64) {
+	for _, e := range o.getLoggerExporters() {
+		if e.queue != nil {
+			n += e.queue.Dropped()
+		}
+		n += atomic.LoadUint64(&e.dropped)
+	}
+	return
+}
+
+// GetLoggerQueued
+// returns count of logs waiting in async queues of all logger
+// exporters.
+func (o *provider) GetLoggerQueued() (n int) {
+	for _, e := range o.getLoggerExporters() {
+		if e.queue != nil {
+			n += e.queue.Queued()
+		}
+	}
+	return
+}
+
+// GetTracerDropped
+// returns count of spans discarded by queues of all tracer exporters.
+func (o *provider) GetTracerDropped() (n uint64) {
+	for _, e := range o.getTracerExporters() {
+		if e.queue != nil {
+			n += e.queue.Dropped()
+		}
+	}
+	return
+}
+
+// GetSampler
+// returns sampler consulted on trace creation.
+func (o *provider) GetSampler() Sampler {
+	o.RLock()
+	defer o.RUnlock()
+	return o.sampler
+}
+
 // NewTrace
 // returns a trace with background context.
 func (o *provider) NewTrace(name string) Trace {
@@ -95,8 +188,15 @@ func (o *provider) NewTrace(name string) Trace {
 func (o *provider) NewTraceWithContext(ctx context.Context, name string) Trace {
 	tr := traceCreator(ctx, o, name)
 
-	// Use trace as root.
-	tr.useRoot()
+	// Use remote trace context
+	// extracted by propagator, or use trace as root.
+	if sc, ok := RemoteSpanContextFromContext(ctx); ok && sc.IsValid() {
+		tr.useRemote(sc)
+	} else {
+		tr.useRoot()
+	}
+
+	o.sample(tr)
 	return tr
 }
 
@@ -107,6 +207,7 @@ func (o *provider) NewTraceWithRequest(name string, req *http.Request) Trace {
 
 	// Use trace base on parent http request.
 	tr.useRequest(req)
+	o.sample(tr)
 	return tr
 }
 
@@ -115,33 +216,71 @@ func (o *provider) NewTraceWithRequest(name string, req *http.Request) Trace {
 // /////////////////////////////////////////////////////////////////////////////
 
 func (o *provider) PushBaseLog(level config.LoggerLevel, text string, args ...interface{}) {
-	log := NewLog(LogInternal, level)
-	log.Text = fmt.Sprintf(text, args...)
+	o.pushBaseLog(level, nil, text, args...)
+}
 
-	// Push
-	// log on provider.
-	if o.loggerExporterEnabled {
-		_ = o.loggerExporter.Push(log)
+// PushBaseLogWith
+// send log with structured fields.
+func (o *provider) PushBaseLogWith(level config.LoggerLevel, fields Attr, text string, args ...interface{}) {
+	o.pushBaseLog(level, fields, text, args...)
+}
+
+// PushLog
+// send log to exporters accepted it, log pushed into queue of
+// exporter if async enabled and provider started. Otherwise sent
+// to exporters in sequence, exporter with push timeout is skipped
+// if stalled. Attributes of provider assigned to log if not
+// specified.
+func (o *provider) PushLog(log *Log) {
+	if log.Attr == nil {
+		log.Attr = o.attr
+	}
+
+	for _, e := range o.getLoggerExporters() {
+		if !e.filter.acceptLog(log) {
+			continue
+		}
+		if e.queue != nil && e.queue.Push(log) {
+			continue
+		}
+		if e.filter.pushTimeout > 0 {
+			o.pushLogTimeout(e, log)
+			continue
+		}
+		o.pushLogTo(e, log)
 	}
 }
 
+// PushSpan
+// send span to exporters accepted it, span pushed into queue of
+// exporter if span queue enabled and provider started. Errors of an
+// exporter are reported as log with limited rate and not affect
+// others. Spans of unsampled trace are discarded.
 func (o *provider) PushSpan(span Span) {
-	if o.tracerExporterEnabled {
-		if err := o.tracerExporter.Push(span); err != nil {
-			o.PushBaseLog(config.Error, "push span error: %v", err)
+	if !span.GetTrace().GetSampled() {
+		return
+	}
+
+	for _, e := range o.getTracerExporters() {
+		if !e.filter.acceptSpan(span) {
+			continue
 		}
+		if e.queue != nil {
+			if ok, err := e.queue.Push(span); ok {
+				if err != nil {
+					o.reportSpanError(e, err)
+				}
+				continue
+			}
+		}
+		o.pushSpanTo(e, span)
 	}
 }
 
 func (o *provider) PushSpanLog(level config.LoggerLevel, text string, args ...interface{}) {
 	log := NewLog(LogSpan, level)
 	log.Text = fmt.Sprintf(text, args...)
-
-	// Push
-	// log on provider.
-	if o.loggerExporterEnabled {
-		_ = o.loggerExporter.Push(log)
-	}
+	o.PushLog(log)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -153,14 +292,71 @@ func (o *provider) SetAttr(key string, value interface{}) ProviderManager {
 	return o
 }
 
+// AddLoggerExporter
+// register a logger exporter with filter options, logs sent to all
+// registered exporters. Exporters registered after provider started
+// are not started.
+//
+//	Provider.AddLoggerExporter(logger_term.New())
+//	Provider.AddLoggerExporter(logger_file.New(), WithMinLevel(config.Warn))
+func (o *provider) AddLoggerExporter(e LoggerExporter, opts ...ExporterOption) {
+	if e == nil {
+		return
+	}
+
+	o.Lock()
+	defer o.Unlock()
+
+	list := make([]*loggerExporterEntry, 0, len(o.loggerExporters)+1)
+	list = append(list, o.loggerExporters...)
+	o.loggerExporters = append(list, &loggerExporterEntry{errors: &errorLimiter{}, exporter: e, filter: (&exporterFilter{}).init(opts)})
+}
+
+// AddTracerExporter
+// register a tracer exporter with filter options, spans sent to all
+// registered exporters.
+func (o *provider) AddTracerExporter(e TracerExporter, opts ...ExporterOption) {
+	if e == nil {
+		return
+	}
+
+	o.Lock()
+	defer o.Unlock()
+
+	list := make([]*tracerExporterEntry, 0, len(o.tracerExporters)+1)
+	list = append(list, o.tracerExporters...)
+	o.tracerExporters = append(list, &tracerExporterEntry{errors: &errorLimiter{}, exporter: e, filter: (&exporterFilter{}).init(opts)})
+}
+
+// SetLoggerExporter
+// use specified exporter only, registered exporters removed.
 func (o *provider) SetLoggerExporter(e LoggerExporter) {
-	o.loggerExporter = e
-	o.loggerExporterEnabled = e != nil
+	o.Lock()
+	o.loggerExporters = nil
+	o.Unlock()
+	o.AddLoggerExporter(e)
 }
 
+// SetSampler
+// replace sampler, nil means ParentBased(RuleBased()) which used by
+// default.
+func (o *provider) SetSampler(s Sampler) {
+	if s == nil {
+		s = ParentBased(RuleBased())
+	}
+
+	o.Lock()
+	o.sampler = s
+	o.Unlock()
+}
+
+// SetTracerExporter
+// use specified exporter only, registered exporters removed.
 func (o *provider) SetTracerExporter(e TracerExporter) {
-	o.tracerExporter = e
-	o.tracerExporterEnabled = e != nil
+	o.Lock()
+	o.tracerExporters = nil
+	o.Unlock()
+	o.AddTracerExporter(e)
 }
 
 func (o *provider) Start(ctx context.Context) error {
@@ -175,9 +371,11 @@ func (o *provider) Start(ctx context.Context) error {
 
 	// Lock
 	// provider status.
-	o.ctx, o.cancel = context.WithCancel(ctx)
+	o.ctx, o.cancel = context.WithCancel(ContextWithProvider(ctx, o))
 	o.started = true
 	o.initService()
+	o.initLoggerQueues()
+	o.initTracerQueues()
 	o.Unlock()
 
 	go func(call, end func()) {
@@ -225,6 +423,7 @@ func (o *provider) Stop() bool {
 
 func (o *provider) init() *provider {
 	o.attr = Attr{}
+	o.sampler = ParentBased(RuleBased())
 	return o.initRuntime()
 }
 
@@ -257,6 +456,41 @@ func (o *provider) initRuntime() *provider {
 	return o
 }
 
+// initLoggerQueues
+// create a bounded queue for each logger exporter if async enabled,
+// a slow exporter does not block others.
+func (o *provider) initLoggerQueues() {
+	c := config.Config.GetLoggerAsync()
+	list := make([]*loggerExporterEntry, 0, len(o.loggerExporters))
+
+	for _, e := range o.loggerExporters {
+		x := &loggerExporterEntry{errors: e.errors, exporter: e.exporter, filter: e.filter}
+		if c.GetEnabled() {
+			x.queue = (&logQueue{}).init(c.GetCapacity(), c.GetPolicy())
+			x.queue.Start()
+		}
+		list = append(list, x)
+	}
+	o.loggerExporters = list
+}
+
+// initTracerQueues
+// create a bounded queue for each tracer exporter with span queue
+// enabled, a slow exporter does not block others and span creators.
+func (o *provider) initTracerQueues() {
+	list := make([]*tracerExporterEntry, 0, len(o.tracerExporters))
+
+	for _, e := range o.tracerExporters {
+		x := &tracerExporterEntry{errors: e.errors, exporter: e.exporter, filter: e.filter}
+		if n := e.filter.spanQueue; n > 0 {
+			x.queue = (&spanQueue{}).init(n)
+			x.queue.Start()
+		}
+		list = append(list, x)
+	}
+	o.tracerExporters = list
+}
+
 func (o *provider) initService() *provider {
 	o.attr.Add("service.name", config.Config.GetServiceName())
 	o.attr.Add("service.port", config.Config.GetServicePort())
@@ -274,6 +508,132 @@ func (o *provider) debugger(text string, args ...interface{}) {
 	}
 }
 
+// sample
+// decide sampled flag of trace, debug flag of parent forces sampling.
+func (o *provider) sample(tr *trace) {
+	tr.sampled = tr.debug || o.GetSampler().Sample(SamplingParameters{
+		Attr:    tr.attr,
+		Name:    tr.name,
+		Parent:  tr.parent,
+		TraceId: tr.traceId,
+	})
+}
+
+func (o *provider) getLoggerExporters() []*loggerExporterEntry {
+	o.RLock()
+	defer o.RUnlock()
+	return o.loggerExporters
+}
+
+func (o *provider) getTracerExporters() []*tracerExporterEntry {
+	o.RLock()
+	defer o.RUnlock()
+	return o.tracerExporters
+}
+
+// pushBaseLog
+// send internal log, called by exported pusher only.
+func (o *provider) pushBaseLog(level config.LoggerLevel, fields Attr, text string, args ...interface{}) {
+	log := NewLog(LogInternal, level)
+	log.Text = fmt.Sprintf(text, args...)
+
+	if len(fields) > 0 {
+		log.Fields = Attr{}
+		log.Fields.Copy(fields)
+	}
+
+	// Caller
+	// of exported function, such as log.Info().
+	if config.Config.GetLoggerCaller() {
+		log.Caller = logCaller(3)
+	}
+
+	o.PushLog(log)
+}
+
+// pushLogTo
+// send log to an exporter, error ignored and panic recovered.
+func (o *provider) pushLogTo(e *loggerExporterEntry, log *Log) {
+	defer func() {
+		if r := recover(); r != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "logger exporter panic: %v\n", r)
+		}
+	}()
+
+	_ = e.exporter.Push(log)
+}
+
+// pushLogTimeout
+// send log to an exporter in coroutine, returns when sent or push
+// timeout elapsed. Exporter exceeded timeout is skipped until its
+// push returned, logs skipped are counted and reported.
+func (o *provider) pushLogTimeout(e *loggerExporterEntry, log *Log) {
+	if atomic.LoadInt32(&e.stalled) > 0 {
+		n := atomic.AddUint64(&e.dropped, 1)
+		if suppressed, ok := e.errors.allow(); ok {
+			_, _ = fmt.Fprintf(os.Stderr, "logger exporter stalled: log dropped, %d logs dropped in total, %d reports suppressed\n", n, suppressed)
+		}
+		return
+	}
+
+	var (
+		done  = make(chan bool, 1)
+		state = pushRunning
+	)
+
+	go func() {
+		o.pushLogTo(e, log)
+
+		// Stalled
+		// push returned after timeout.
+		if !atomic.CompareAndSwapInt32(&state, pushRunning, pushFinished) {
+			atomic.AddInt32(&e.stalled, -1)
+		}
+		done <- true
+	}()
+
+	timer := time.NewTimer(e.filter.pushTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+	case <-timer.C:
+		if atomic.CompareAndSwapInt32(&state, pushRunning, pushStalled) {
+			atomic.AddInt32(&e.stalled, 1)
+		}
+	}
+}
+
+// pushSpanTo
+// send span to an exporter, panic recovered as error. Errors are
+// reported with limited rate.
+func (o *provider) pushSpanTo(e *tracerExporterEntry, span Span) {
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		return e.exporter.Push(span)
+	}()
+
+	if err != nil {
+		o.reportSpanError(e, err)
+	}
+}
+
+// reportSpanError
+// report error of tracer exporter as log with limited rate.
+func (o *provider) reportSpanError(e *tracerExporterEntry, err error) {
+	if suppressed, ok := e.errors.allow(); ok {
+		if suppressed > 0 {
+			o.PushBaseLog(config.Error, "push span error: %v, %d errors suppressed", err, suppressed)
+		} else {
+			o.PushBaseLog(config.Error, "push span error: %v", err)
+		}
+	}
+}
+
 func (o *provider) start() {
 	// Start
 	// in 3 coroutines.
@@ -297,31 +657,151 @@ func (o *provider) startEnd() {
 	o.debugger("end process")
 }
 
+// startLogger
+// start logger exporters in coroutines, returns when all of them
+// stopped.
 func (o *provider) startLogger() {
-	if o.loggerExporterEnabled {
-		if err := o.loggerExporter.Start(o.ctx); err != nil {
-			o.debugger("end logger: %v", err)
-		} else {
-			o.debugger("end logger")
+	wait := &sync.WaitGroup{}
+	for _, e := range o.getLoggerExporters() {
+		wait.Add(1)
+		go func(e *loggerExporterEntry) {
+			defer wait.Done()
+			if err := o.startLoggerExporter(e); err != nil {
+				o.debugger("end logger: %v", err)
+			} else {
+				o.debugger("end logger")
+			}
+		}(e)
+	}
+	wait.Wait()
+}
+
+func (o *provider) startLoggerExporter(e *loggerExporterEntry) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
 		}
+	}()
+
+	q := e.queue
+	if q == nil {
+		return e.exporter.Start(o.ctx)
 	}
+
+	// Exporter context
+	// cancelled after queue drained, exporter can flush
+	// remained logs when stopped.
+	var (
+		ctx, cancel = context.WithCancel(ContextWithProvider(context.Background(), o))
+		wait        = &sync.WaitGroup{}
+	)
+
+	wait.Add(2)
+	go func() {
+		defer wait.Done()
+		<-o.ctx.Done()
+		q.Stop()
+	}()
+	go func() {
+		defer wait.Done()
+		defer cancel()
+		for {
+			log, ok := q.Pop()
+			if !ok {
+				return
+			}
+			o.pushLogTo(e, log)
+		}
+	}()
+
+	err = e.exporter.Start(ctx)
+	wait.Wait()
+	return
 }
 
+// startProvider
+// start sampler if it has background work, returns when provider
+// stopped.
 func (o *provider) startProvider() {
-	for {
-		select {
-		case <-o.ctx.Done():
-			return
+	s := o.GetSampler()
+	if x, ok := s.(*parentBasedSampler); ok {
+		s = x.root
+	}
+
+	if x, ok := s.(SamplerStarter); ok {
+		if err := o.startSampler(x); err != nil {
+			o.debugger("end sampler: %v", err)
+		} else {
+			o.debugger("end sampler")
 		}
 	}
+
+	<-o.ctx.Done()
 }
 
+func (o *provider) startSampler(s SamplerStarter) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return s.Start(o.ctx)
+}
+
+// startTracer
+// start tracer exporters in coroutines, returns when all of them
+// stopped.
 func (o *provider) startTracer() {
-	if o.tracerExporterEnabled {
-		if err := o.tracerExporter.Start(o.ctx); err != nil {
-			o.debugger("end tracer: %v", err)
-		} else {
-			o.debugger("end tracer")
+	wait := &sync.WaitGroup{}
+	for _, e := range o.getTracerExporters() {
+		wait.Add(1)
+		go func(e *tracerExporterEntry) {
+			defer wait.Done()
+			if err := o.startTracerExporter(e); err != nil {
+				o.debugger("end tracer: %v", err)
+			} else {
+				o.debugger("end tracer")
+			}
+		}(e)
+	}
+	wait.Wait()
+}
+
+func (o *provider) startTracerExporter(e *tracerExporterEntry) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
 		}
+	}()
+
+	q := e.queue
+	if q == nil {
+		return e.exporter.Start(o.ctx)
 	}
+
+	// Exporter context
+	// cancelled after queue drained, exporter can flush
+	// remained spans when stopped.
+	ctx, cancel := context.WithCancel(ContextWithProvider(context.Background(), o))
+	go func() {
+		defer cancel()
+		for {
+			select {
+			case span := <-q.items:
+				o.pushSpanTo(e, span)
+			case <-o.ctx.Done():
+				q.Stop()
+				for {
+					select {
+					case span := <-q.items:
+						o.pushSpanTo(e, span)
+					default:
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return e.exporter.Start(ctx)
 }
@@ -16,9 +16,64 @@
 package tracer
 
 import (
+	"context"
+	"errors"
+	"github.com/fuyibing/log/config"
+	"sync"
 	"testing"
+	"time"
 )
 
+type (
+	testLoggerExporter struct {
+		sync.Mutex
+		block chan bool
+		fail  bool
+		logs  []*Log
+	}
+
+	testTracerExporter struct {
+		sync.Mutex
+		block chan bool
+		spans []Span
+	}
+)
+
+func (o *testLoggerExporter) Push(log *Log) error {
+	if o.block != nil {
+		<-o.block
+	}
+	if o.fail {
+		panic("exporter broken")
+	}
+	o.Lock()
+	o.logs = append(o.logs, log)
+	o.Unlock()
+	return nil
+}
+
+func (o *testLoggerExporter) Start(ctx context.Context) error { <-ctx.Done(); return nil }
+func (o *testLoggerExporter) Stopped() bool                   { return true }
+
+func (o *testLoggerExporter) count() int {
+	o.Lock()
+	defer o.Unlock()
+	return len(o.logs)
+}
+
+func (o *testTracerExporter) Push(span Span) error {
+	if o.block != nil {
+		<-o.block
+	}
+	o.Lock()
+	o.spans = append(o.spans, span)
+	o.Unlock()
+	return errors.New("ignored")
+}
+
+func (o *testTracerExporter) Start(ctx context.Context) error { <-ctx.Done(); return nil }
+func (o *testTracerExporter) Stopped() bool                   { return true }
+
 func TestProvider_NewTrace(t *testing.T) {
 	t.Logf("provider attr: %v", Provider.GetAttr().JSON())
 
@@ -30,3 +85,207 @@ func TestProvider_NewTrace(t *testing.T) {
 	s1.SetAttr("key", "value")
 	t.Logf("attr: %v", s1.GetAttr().JSON())
 }
+
+func TestProvider_AddLoggerExporter(t *testing.T) {
+	var (
+		p      = (&provider{}).init()
+		all    = &testLoggerExporter{}
+		warn   = &testLoggerExporter{}
+		filter = &testLoggerExporter{}
+		broken = &testLoggerExporter{fail: true}
+	)
+
+	p.AddLoggerExporter(broken)
+	p.AddLoggerExporter(all)
+	p.AddLoggerExporter(warn, WithMinLevel(config.Warn))
+	p.AddLoggerExporter(filter, WithLogFilter(func(log *Log) bool { return log.Text == "keep" }))
+
+	for _, level := range []config.LoggerLevel{config.Debug, config.Info, config.Warn, config.Error} {
+		x := NewLog(LogInternal, level)
+		x.Text = "keep"
+		p.PushLog(x)
+	}
+	p.PushLog(NewLog(LogInternal, config.Fatal))
+
+	if n := all.count(); n != 5 {
+		t.Errorf("expect 5 logs, got %d", n)
+	}
+	if n := warn.count(); n != 3 {
+		t.Errorf("expect 3 logs of warn or more severe, got %d", n)
+	}
+	if n := filter.count(); n != 4 {
+		t.Errorf("expect 4 filtered logs, got %d", n)
+	}
+
+	p.SetLoggerExporter(all)
+	if n := len(p.getLoggerExporters()); n != 1 {
+		t.Errorf("expect 1 exporter after set, got %d", n)
+	}
+}
+
+func TestProvider_AddLoggerExporterAsync(t *testing.T) {
+	config.Config.With(config.LoggerAsyncEnabled(true))
+	defer config.Config.With(config.LoggerAsyncEnabled(false))
+
+	var (
+		p    = (&provider{}).init()
+		fast = &testLoggerExporter{}
+		slow = &testLoggerExporter{block: make(chan bool)}
+	)
+
+	p.AddLoggerExporter(slow)
+	p.AddLoggerExporter(fast)
+	if err := p.Start(context.Background()); err != nil {
+		t.Fatalf("start error: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		p.PushLog(NewLog(LogInternal, config.Info))
+	}
+
+	// Fast
+	// exporter not blocked by slow exporter.
+	deadline := time.Now().Add(time.Second)
+	for fast.count() < 10 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := fast.count(); n != 10 {
+		t.Errorf("expect 10 logs of fast exporter, got %d", n)
+	}
+
+	close(slow.block)
+	p.Stop()
+
+	if n := slow.count(); n != 10 {
+		t.Errorf("expect 10 logs of slow exporter, got %d", n)
+	}
+}
+
+func TestProvider_AddLoggerExporterStalled(t *testing.T) {
+	var (
+		p    = (&provider{}).init()
+		fast = &testLoggerExporter{}
+		slow = &testLoggerExporter{block: make(chan bool)}
+	)
+
+	p.AddLoggerExporter(slow, WithPushTimeout(20*time.Millisecond))
+	p.AddLoggerExporter(fast)
+
+	// Stalled
+	// exporter skipped until push returned.
+	begin := time.Now()
+	for i := 0; i < 3; i++ {
+		p.PushLog(NewLog(LogInternal, config.Info))
+	}
+	if d := time.Since(begin); d > time.Second {
+		t.Errorf("expect push not blocked by stalled exporter, took %s", d)
+	}
+	if n := fast.count(); n != 3 {
+		t.Errorf("expect 3 logs of fast exporter, got %d", n)
+	}
+	if n := p.GetLoggerDropped(); n != 2 {
+		t.Errorf("expect 2 logs dropped, got %d", n)
+	}
+
+	// Recovered
+	// after push returned.
+	close(slow.block)
+	deadline := time.Now().Add(time.Second)
+	for slow.count() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	p.PushLog(NewLog(LogInternal, config.Info))
+	if n := slow.count(); n != 2 {
+		t.Errorf("expect 2 logs of recovered exporter, got %d", n)
+	}
+}
+
+func TestProvider_AddTracerExporter(t *testing.T) {
+	var (
+		p      = (&provider{}).init()
+		all    = &testTracerExporter{}
+		filter = &testTracerExporter{}
+	)
+
+	p.AddTracerExporter(all)
+	p.AddTracerExporter(filter, WithSpanFilter(func(span Span) bool { return span.GetName() == "keep" }))
+
+	tr := p.NewTrace("trace")
+	p.PushSpan(tr.NewSpan("keep"))
+	p.PushSpan(tr.NewSpan("drop"))
+
+	if len(all.spans) != 2 || len(filter.spans) != 1 {
+		t.Errorf("unexpected spans: %d, %d", len(all.spans), len(filter.spans))
+	}
+}
+
+func TestProvider_AddTracerExporterAsync(t *testing.T) {
+	var (
+		p    = (&provider{}).init()
+		fast = &testTracerExporter{}
+		slow = &testTracerExporter{block: make(chan bool)}
+		logs = &testLoggerExporter{}
+	)
+
+	p.AddLoggerExporter(logs)
+	p.AddTracerExporter(slow, WithSpanQueue(64))
+	p.AddTracerExporter(fast, WithSpanQueue(64))
+	if err := p.Start(context.Background()); err != nil {
+		t.Fatalf("start error: %v", err)
+	}
+
+	tr := p.NewTrace("trace")
+	for i := 0; i < 10; i++ {
+		p.PushSpan(tr.NewSpan("span"))
+	}
+
+	// Fast
+	// exporter not blocked by slow exporter.
+	deadline := time.Now().Add(time.Second)
+	for fast.count() < 10 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := fast.count(); n != 10 {
+		t.Errorf("expect 10 spans of fast exporter, got %d", n)
+	}
+
+	close(slow.block)
+	p.Stop()
+
+	if n := slow.count(); n != 10 {
+		t.Errorf("expect 10 spans of slow exporter drained, got %d", n)
+	}
+
+	// Errors
+	// reported once per interval for each exporter.
+	if n := logs.count(); n != 2 {
+		t.Errorf("expect 2 error logs, got %d", n)
+	}
+}
+
+func TestProvider_AddTracerExporterQueueFull(t *testing.T) {
+	var (
+		p    = (&provider{}).init()
+		e    = &testTracerExporter{}
+		logs = &testLoggerExporter{}
+	)
+
+	p.AddLoggerExporter(logs)
+	p.AddTracerExporter(e, WithSpanQueue(1))
+
+	// Queue
+	// without consumer, the second span discarded.
+	p.initTracerQueues()
+	tr := p.NewTrace("trace")
+	for i := 0; i < 3; i++ {
+		p.PushSpan(tr.NewSpan("span"))
+	}
+
+	if n := p.GetTracerDropped(); n != 2 {
+		t.Errorf("expect 2 spans dropped, got %d", n)
+	}
+	if n := logs.count(); n != 1 {
+		t.Errorf("expect 1 error log of dropped spans, got %d", n)
+	}
+}
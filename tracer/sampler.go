@@ -0,0 +1,187 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+type (
+	// Sampler
+	// decide whether a trace is sampled when it is created. Spans of
+	// unsampled trace are not exported, trace context still propagated
+	// with sampled flag unset.
+	Sampler interface {
+		// Sample
+		// return true if trace should be sampled.
+		Sample(p SamplingParameters) bool
+
+		// String
+		// returns description of sampler, such as TraceIdRatio{0.1}.
+		String() string
+	}
+
+	// SamplerStarter
+	// a sampler with background work, such as fetching remote
+	// strategies. Started by provider which it is set to, directly or
+	// as root of ParentBased, and stopped when provider stopped.
+	SamplerStarter interface {
+		Sampler
+
+		// Start
+		// run background work until ctx done.
+		Start(ctx context.Context) error
+	}
+
+	// SamplingParameters
+	// information of trace which is creating.
+	SamplingParameters struct {
+		// Attr
+		// attributes of trace, such as http.request.method of
+		// NewTraceWithRequest.
+		Attr Attr
+
+		// Name
+		// name of trace.
+		Name string
+
+		// Parent
+		// remote trace context, invalid for root trace.
+		Parent SpanContext
+
+		// TraceId
+		// id of creating trace.
+		TraceId TraceId
+	}
+
+	alwaysOnSampler  struct{}
+	alwaysOffSampler struct{}
+
+	parentBasedSampler struct {
+		root Sampler
+	}
+
+	rateLimitingSampler struct {
+		sync.Mutex
+
+		balance, max, rate float64
+		last               time.Time
+	}
+
+	traceIdRatioSampler struct {
+		bound uint64
+		ratio float64
+	}
+)
+
+// AlwaysOff
+// returns a sampler never sample.
+func AlwaysOff() Sampler { return alwaysOffSampler{} }
+
+// AlwaysOn
+// returns a sampler always sample.
+func AlwaysOn() Sampler { return alwaysOnSampler{} }
+
+// ParentBased
+// returns a sampler follow sampling decision of remote parent. Root
+// sampler used if trace has no parent, or decision of parent is
+// deferred such as B3 without sample header.
+//
+//	Provider.SetSampler(tracer.ParentBased(tracer.TraceIdRatio(0.1)))
+func ParentBased(root Sampler) Sampler {
+	if root == nil {
+		root = AlwaysOn()
+	}
+	return &parentBasedSampler{root: root}
+}
+
+// RateLimiting
+// returns a sampler sample at most n traces per second, a token bucket
+// with balance up to max(n, 1) credits.
+func RateLimiting(n float64) Sampler {
+	o := &rateLimitingSampler{rate: n, max: math.Max(n, 1), last: time.Now()}
+	o.balance = o.max
+	return o
+}
+
+// TraceIdRatio
+// returns a sampler sample given ratio of traces. Decision is based on
+// lower 64 bits of trace id, services with same ratio make same
+// decision for a trace.
+func TraceIdRatio(ratio float64) Sampler {
+	switch {
+	case ratio >= 1:
+		ratio = 1
+	case ratio <= 0:
+		ratio = 0
+	}
+	return &traceIdRatioSampler{bound: uint64(ratio * (1 << 63)), ratio: ratio}
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Samplers
+// /////////////////////////////////////////////////////////////////////////////
+
+func (alwaysOffSampler) Sample(SamplingParameters) bool { return false }
+func (alwaysOffSampler) String() string                 { return "AlwaysOff" }
+
+func (alwaysOnSampler) Sample(SamplingParameters) bool { return true }
+func (alwaysOnSampler) String() string                 { return "AlwaysOn" }
+
+func (o *parentBasedSampler) Sample(p SamplingParameters) bool {
+	if p.Parent.IsValid() && !p.Parent.Deferred {
+		return p.Parent.Sampled || p.Parent.Debug
+	}
+	return o.root.Sample(p)
+}
+
+func (o *parentBasedSampler) String() string {
+	return fmt.Sprintf("ParentBased{root:%s}", o.root)
+}
+
+func (o *rateLimitingSampler) Sample(SamplingParameters) bool {
+	o.Lock()
+	defer o.Unlock()
+
+	// Refill
+	// credits elapsed since last sampling.
+	now := time.Now()
+	o.balance = math.Min(o.max, o.balance+now.Sub(o.last).Seconds()*o.rate)
+	o.last = now
+
+	if o.balance >= 1 {
+		o.balance--
+		return true
+	}
+	return false
+}
+
+func (o *rateLimitingSampler) String() string {
+	return fmt.Sprintf("RateLimiting{%g}", o.rate)
+}
+
+func (o *traceIdRatioSampler) Sample(p SamplingParameters) bool {
+	return binary.BigEndian.Uint64(p.TraceId.Byte()[8:16])>>1 < o.bound
+}
+
+func (o *traceIdRatioSampler) String() string {
+	return fmt.Sprintf("TraceIdRatio{%g}", o.ratio)
+}
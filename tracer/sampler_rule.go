@@ -0,0 +1,144 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"strings"
+	"sync"
+)
+
+type (
+	// ruleBasedSampler
+	// sampler of rules in sampling configuration, rules compiled again
+	// if configuration reloaded.
+	ruleBasedSampler struct {
+		sync.Mutex
+
+		fallback Sampler
+		rules    []*samplingRule
+		source   config.SamplingConfiguration
+	}
+
+	samplingRule struct {
+		config.SamplingRule
+		sampler Sampler
+	}
+)
+
+// RuleBased
+// returns a sampler of rules in sampling section of configuration.
+// Rules matched on trace name, http method and path of trace created by
+// NewTraceWithRequest, the first matched rule is used.
+func RuleBased() Sampler {
+	return &ruleBasedSampler{}
+}
+
+func (o *ruleBasedSampler) Sample(p SamplingParameters) bool {
+	rules, fallback := o.compile()
+
+	var (
+		method = o.attr(p.Attr, "http.request.method")
+		path   = o.attr(p.Attr, "http.request.url")
+	)
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+
+	for _, r := range rules {
+		if r.match(p.Name, method, path) {
+			return r.sampler.Sample(p)
+		}
+	}
+	return fallback.Sample(p)
+}
+
+func (o *ruleBasedSampler) String() string {
+	rules, fallback := o.compile()
+	return fmt.Sprintf("RuleBased{rules:%d,default:%s}", len(rules), fallback)
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Rule based sampler: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *ruleBasedSampler) attr(attr Attr, key string) string {
+	if v, ok := attr[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// compile
+// returns rules of current configuration. Rate limiters of rules are
+// kept until configuration replaced.
+func (o *ruleBasedSampler) compile() ([]*samplingRule, Sampler) {
+	o.Lock()
+	defer o.Unlock()
+
+	c := config.Config.GetSampling()
+	if o.source == c && o.fallback != nil {
+		return o.rules, o.fallback
+	}
+
+	o.source = c
+	o.fallback = samplerOf(c.GetDefaultRatio(), c.GetDefaultRateLimit())
+	o.rules = make([]*samplingRule, 0, len(c.GetRules()))
+	for _, r := range c.GetRules() {
+		o.rules = append(o.rules, &samplingRule{SamplingRule: r, sampler: samplerOf(r.Ratio, r.RateLimit)})
+	}
+	return o.rules, o.fallback
+}
+
+func (o *samplingRule) match(name, method, path string) bool {
+	return (o.Name == "" || matchPattern(o.Name, name)) &&
+		(o.Method == "" || matchPattern(strings.ToUpper(o.Method), strings.ToUpper(method))) &&
+		(o.Path == "" || matchPattern(o.Path, path))
+}
+
+// matchPattern
+// return true if s matches pattern, * matches any sequence.
+func matchPattern(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
+
+// samplerOf
+// returns rate limiting sampler if limit specified, otherwise ratio
+// sampler.
+func samplerOf(ratio, limit float64) Sampler {
+	if limit > 0 {
+		return RateLimiting(limit)
+	}
+	return TraceIdRatio(ratio)
+}
@@ -0,0 +1,76 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"github.com/fuyibing/log/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMatchPattern(t *testing.T) {
+	for _, c := range []struct {
+		pattern, s string
+		expect     bool
+	}{
+		{"/health", "/health", true},
+		{"/health", "/healthz", false},
+		{"/health*", "/healthz", true},
+		{"*/metrics", "/api/metrics", true},
+		{"/api/*/users/*", "/api/v1/users/3", true},
+		{"/api/*/users/*", "/api/v1/orders/3", false},
+		{"*", "", true},
+	} {
+		if got := matchPattern(c.pattern, c.s); got != c.expect {
+			t.Errorf("match %q with %q: expect %v, got %v", c.pattern, c.s, c.expect, got)
+		}
+	}
+}
+
+func TestRuleBased(t *testing.T) {
+	config.Config.With(config.SamplingRules(1,
+		config.SamplingRule{Path: "/health*", Ratio: 0},
+		config.SamplingRule{Method: "get", Path: "/metrics", RateLimit: 1},
+		config.SamplingRule{Name: "cron.*", Ratio: 0},
+	))
+	defer config.Config.With(config.SamplingRules(1))
+
+	p := NewProvider()
+	request := func(method, target string) bool {
+		return p.NewTraceWithRequest("request", httptest.NewRequest(method, target, nil)).GetSampled()
+	}
+
+	if request(http.MethodGet, "/healthz?full=1") {
+		t.Errorf("expect health check not sampled")
+	}
+	if !request(http.MethodGet, "/metrics") || request(http.MethodGet, "/metrics") {
+		t.Errorf("expect metrics sampled once per second")
+	}
+	if !request(http.MethodPost, "/metrics") {
+		t.Errorf("expect default ratio used for other method")
+	}
+	if p.NewTrace("cron.cleanup").GetSampled() || !p.NewTrace("job").GetSampled() {
+		t.Errorf("unexpected decision of trace name rule")
+	}
+
+	// Reload
+	// rules replaced.
+	config.Config.With(config.SamplingRules(0, config.SamplingRule{Path: "/healthz", Ratio: 1}))
+	if request(http.MethodGet, "/api") || !request(http.MethodGet, "/healthz") {
+		t.Errorf("expect rules replaced on reload")
+	}
+}
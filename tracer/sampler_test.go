@@ -0,0 +1,126 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTraceIdRatio(t *testing.T) {
+	var (
+		n = 0
+		s = TraceIdRatio(0.25)
+	)
+	for i := 0; i < 10000; i++ {
+		if s.Sample(SamplingParameters{TraceId: Identify.NewTraceId()}) {
+			n++
+		}
+	}
+	if n < 2200 || n > 2800 {
+		t.Errorf("expect about 2500 sampled, got %d", n)
+	}
+
+	// Consistent
+	// decision for same trace id.
+	tid := Identify.NewTraceId()
+	a, b := TraceIdRatio(0.5).Sample(SamplingParameters{TraceId: tid}), TraceIdRatio(0.5).Sample(SamplingParameters{TraceId: tid})
+	if a != b {
+		t.Errorf("expect same decision of trace id")
+	}
+
+	if TraceIdRatio(0).Sample(SamplingParameters{TraceId: tid}) || !TraceIdRatio(1).Sample(SamplingParameters{TraceId: tid}) {
+		t.Errorf("unexpected decision of ratio 0 or 1")
+	}
+}
+
+func TestParentBased(t *testing.T) {
+	s := ParentBased(AlwaysOff())
+	parent := SpanContext{TraceId: Identify.NewTraceId(), SpanId: Identify.NewSpanId()}
+
+	for _, c := range []struct {
+		sampled, debug, deferred, expect bool
+	}{
+		{true, false, false, true},
+		{false, false, false, false},
+		{false, true, false, true},
+		{true, false, true, false},
+	} {
+		parent.Sampled, parent.Debug, parent.Deferred = c.sampled, c.debug, c.deferred
+		if got := s.Sample(SamplingParameters{Parent: parent}); got != c.expect {
+			t.Errorf("parent %+v: expect %v, got %v", c, c.expect, got)
+		}
+	}
+
+	if !ParentBased(AlwaysOn()).Sample(SamplingParameters{}) {
+		t.Errorf("expect root sampler used without parent")
+	}
+}
+
+func TestRateLimiting(t *testing.T) {
+	s := RateLimiting(2)
+
+	n := 0
+	for i := 0; i < 10; i++ {
+		if s.Sample(SamplingParameters{}) {
+			n++
+		}
+	}
+	if n != 2 {
+		t.Errorf("expect 2 sampled, got %d", n)
+	}
+
+	time.Sleep(600 * time.Millisecond)
+	if !s.Sample(SamplingParameters{}) {
+		t.Errorf("expect credit refilled")
+	}
+}
+
+func TestProviderSampler(t *testing.T) {
+	p := NewProvider()
+	e := &testTracerExporter{}
+	p.AddTracerExporter(e)
+	p.SetSampler(AlwaysOff())
+
+	tr := p.NewTrace("unsampled")
+	tr.NewSpan("span").End()
+	if tr.GetSampled() || len(e.spans) != 0 {
+		t.Fatalf("expect unsampled span not exported")
+	}
+
+	// Propagated
+	// with sampled flag unset.
+	out := MapCarrier{}
+	(&W3cPropagator{}).Inject(tr.NewSpan("call").GetContext(), out)
+	if s := out["traceparent"]; len(s) != 55 || s[53:] != "00" {
+		t.Errorf("unexpected traceparent: %s", s)
+	}
+
+	// Debug
+	// flag of parent forces sampling.
+	ctx := (&JaegerPropagator{}).Extract(context.Background(), MapCarrier{"uber-trace-id": "a3ce929d0e0e4736:f067aa0ba902b7:0:2"})
+	tr = p.NewTraceWithContext(ctx, "debug")
+	tr.NewSpan("span").End()
+	if !tr.GetSampled() || len(e.spans) != 1 {
+		t.Errorf("expect debug trace exported")
+	}
+
+	p.SetSampler(nil)
+	if p.GetSampler().String() != "ParentBased{root:RuleBased{rules:0,default:TraceIdRatio{1}}}" {
+		t.Errorf("unexpected default sampler: %s", p.GetSampler())
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"github.com/fuyibing/log/config"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -57,6 +58,10 @@ type (
 		trace *trace
 
 		startTime, endTime time.Time
+
+		// ended
+		// set to 1 when span ended, accessed atomically.
+		ended int32
 	}
 
 	// spanGetter interface for span reader.
@@ -65,6 +70,11 @@ type (
 		// returns an attribute fields.
 		GetAttr() Attr
 
+		// GetContext
+		// returns a context with the span stored, pass it to
+		// StartSpan or NewSpanWithContext to create children.
+		GetContext() context.Context
+
 		// GetDuration
 		// return span duration.
 		GetDuration() time.Duration
@@ -104,6 +114,9 @@ type (
 
 	// spanLogger interface for log sender.
 	spanLogger interface {
+		// Trace send trace level log on span.
+		Trace(text string, args ...interface{})
+
 		// Debug send debug level log on span.
 		Debug(text string, args ...interface{})
 
@@ -116,6 +129,16 @@ type (
 		// Info send info level log on span.
 		Info(text string, args ...interface{})
 
+		// Log send specified level log on span, custom level supported.
+		Log(level config.LoggerLevel, text string, args ...interface{})
+
+		// LogWith send specified level log with structured fields on
+		// span, fields override baggage fields of same key.
+		LogWith(level config.LoggerLevel, fields Attr, text string, args ...interface{})
+
+		// Notice send notice level log on span.
+		Notice(text string, args ...interface{})
+
 		// Warn send warn level log on span.
 		Warn(text string, args ...interface{})
 	}
@@ -147,16 +170,21 @@ type (
 
 func (o *span) NewSpan(name string) Span {
 	v := (&span{}).init(name)
+	v.ctx = ContextWithSpan(o.ctx, v)
 	v.parentSpanId = o.spanId
 	v.trace = o.trace
+	v.useBaggage()
+	o.trace.addSpan()
 	return v
 }
 
 func (o *span) NewSpanWithContext(ctx context.Context, name string) Span {
 	v := (&span{}).init(name)
-	v.ctx = ctx
+	v.ctx = ContextWithSpan(ctx, v)
 	v.parentSpanId = o.spanId
 	v.trace = o.trace
+	v.useBaggage()
+	o.trace.addSpan()
 	return v
 }
 
@@ -168,6 +196,10 @@ func (o *span) NewSpanWithContext(ctx context.Context, name string) Span {
 // returns an attribute fields.
 func (o *span) GetAttr() Attr { return o.attr }
 
+// GetContext
+// returns a context with the span stored.
+func (o *span) GetContext() context.Context { return o.ctx }
+
 // GetDuration
 // return span duration.
 func (o *span) GetDuration() time.Duration { return o.endTime.Sub(o.startTime) }
@@ -210,11 +242,19 @@ func (o *span) GetTraceId() TraceId { return o.trace.traceId }
 // Span: setter
 // /////////////////////////////////////////////////////////////////////////////
 
+// End
+// record end time and push span to exporters, span is ended once and
+// calls after the first are ignored.
 func (o *span) End() {
+	if !atomic.CompareAndSwapInt32(&o.ended, 0, 1) {
+		return
+	}
+
 	o.Lock()
 	o.endTime = time.Now()
 	o.Unlock()
 
+	o.trace.endSpan()
 	o.trace.GetProvider().PushSpan(o)
 }
 
@@ -231,38 +271,66 @@ func (o *span) SetAttr(key string, value interface{}) Span {
 // Span: logger
 // /////////////////////////////////////////////////////////////////////////////
 
+// Trace send trace level log on span.
+func (o *span) Trace(text string, args ...interface{}) {
+	if config.Config.TraceOn() {
+		o.sendLog(config.Trace, nil, text, args...)
+	}
+}
+
 // Debug send debug level log on span.
 func (o *span) Debug(text string, args ...interface{}) {
 	if config.Config.DebugOn() {
-		o.sendLog(config.Debug, text, args...)
+		o.sendLog(config.Debug, nil, text, args...)
 	}
 }
 
 // Info send info level log on span.
 func (o *span) Info(text string, args ...interface{}) {
 	if config.Config.InfoOn() {
-		o.sendLog(config.Info, text, args...)
+		o.sendLog(config.Info, nil, text, args...)
+	}
+}
+
+// Notice send notice level log on span.
+func (o *span) Notice(text string, args ...interface{}) {
+	if config.Config.NoticeOn() {
+		o.sendLog(config.Notice, nil, text, args...)
 	}
 }
 
 // Warn send warn level log on span.
 func (o *span) Warn(text string, args ...interface{}) {
 	if config.Config.WarnOn() {
-		o.sendLog(config.Warn, text, args...)
+		o.sendLog(config.Warn, nil, text, args...)
 	}
 }
 
 // Error send error level log on span.
 func (o *span) Error(text string, args ...interface{}) {
 	if config.Config.ErrorOn() {
-		o.sendLog(config.Error, text, args...)
+		o.sendLog(config.Error, nil, text, args...)
 	}
 }
 
 // Fatal send fatal level log on span.
 func (o *span) Fatal(text string, args ...interface{}) {
 	if config.Config.FatalOn() {
-		o.sendLog(config.Fatal, text, args...)
+		o.sendLog(config.Fatal, nil, text, args...)
+	}
+}
+
+// Log send specified level log on span, custom level supported.
+func (o *span) Log(level config.LoggerLevel, text string, args ...interface{}) {
+	if config.Config.LevelOn(level) {
+		o.sendLog(level, nil, text, args...)
+	}
+}
+
+// LogWith send specified level log with structured fields on span.
+func (o *span) LogWith(level config.LoggerLevel, fields Attr, text string, args ...interface{}) {
+	if config.Config.LevelOn(level) {
+		o.sendLog(level, fields, text, args...)
 	}
 }
 
@@ -279,19 +347,46 @@ func (o *span) init(name string) *span {
 	return o
 }
 
-func (o *span) sendLog(level config.LoggerLevel, text string, args ...interface{}) {
-	// Create log and add
+// useBaggage
+// copy baggage members of configured keys into attributes.
+func (o *span) useBaggage() {
+	if attr := baggageAttr(o.ctx, config.Config.GetBaggage().GetSpanAttributes()); attr != nil {
+		o.attr.Copy(attr)
+	}
+}
+
+func (o *span) sendLog(level config.LoggerLevel, fields Attr, text string, args ...interface{}) {
+	x := NewLog(LogSpan, level)
+	x.Text = fmt.Sprintf(text, args...)
+	x.Fields = baggageAttr(o.ctx, config.Config.GetBaggage().GetLogFields())
+
+	// Fields
+	// override baggage fields.
+	if len(fields) > 0 {
+		if x.Fields == nil {
+			x.Fields = Attr{}
+		}
+		x.Fields.Copy(fields)
+	}
+	x.SpanId = o.spanId
+	x.TraceId = o.trace.traceId
+
+	// Caller
+	// of span logger, such as span.Info().
+	if config.Config.GetLoggerCaller() {
+		x.Caller = logCaller(2)
+	}
+
+	// Add log
 	// into span containers.
 	if config.Config.GetTracerWithLog() {
 		o.Lock()
-		x := NewLog(LogSpan, level)
-		x.Text = fmt.Sprintf(text, args...)
 		o.logs = append(o.logs, x)
 		o.Unlock()
 	}
 
 	// Publish to basic.
-	o.trace.GetProvider().PushSpanLog(config.Fatal, text, args...)
+	o.trace.GetProvider().PushLog(x)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -300,6 +395,7 @@ func (o *span) sendLog(level config.LoggerLevel, text string, args ...interface{
 
 func (o SpanId) Byte() []byte   { return o.bs[:] }
 func (o SpanId) Err() error     { return o.err }
+func (o SpanId) IsZero() bool   { return o.bs == [8]byte{} }
 func (o SpanId) Security() bool { return o.security }
 
 func (o SpanId) String() string {
@@ -0,0 +1,90 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	errSpanQueueFull = errors.New("span queue is full, span dropped")
+)
+
+type (
+	// spanQueue
+	// a bounded queue of spans consumed by coroutine of a tracer
+	// exporter.
+	spanQueue struct {
+		sync.Mutex
+
+		items   chan Span
+		running bool
+
+		dropped uint64
+	}
+)
+
+// Dropped
+// returns count of discarded spans.
+func (o *spanQueue) Dropped() uint64 { return atomic.LoadUint64(&o.dropped) }
+
+// Push
+// span into queue, returns false if queue is not running and caller
+// should send it synchronously. Span discarded with errSpanQueueFull
+// returned if queue is full.
+func (o *spanQueue) Push(span Span) (bool, error) {
+	o.Lock()
+	defer o.Unlock()
+
+	if !o.running {
+		return false, nil
+	}
+
+	select {
+	case o.items <- span:
+		return true, nil
+	default:
+		atomic.AddUint64(&o.dropped, 1)
+		return true, errSpanQueueFull
+	}
+}
+
+// Start
+// accept spans.
+func (o *spanQueue) Start() {
+	o.Lock()
+	o.running = true
+	o.Unlock()
+}
+
+// Stop
+// refuse new spans, spans pushed before stopped remain in items.
+func (o *spanQueue) Stop() {
+	o.Lock()
+	o.running = false
+	o.Unlock()
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Queue: access
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *spanQueue) init(capacity int) *spanQueue {
+	o.items = make(chan Span, capacity)
+	return o
+}
@@ -0,0 +1,561 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultTailDecisionWait = 10 * time.Second
+	DefaultTailMaxSpans     = 100000
+	DefaultTailMaxTraces    = 10000
+)
+
+type (
+	// TailPolicy
+	// decide whether a buffered trace is kept, trace kept if any policy
+	// returns true.
+	TailPolicy interface {
+		Evaluate(spans []Span) bool
+		String() string
+	}
+
+	// TailSampler
+	// a tracer exporter buffer spans per trace, spans forwarded to
+	// wrapped exporter after trace completed and kept by policies. A
+	// trace is completed when all spans created on it ended, or decision
+	// wait elapsed since its first span. Spans filtered by exporter
+	// options never reach sampler, trace completed without them.
+	//
+	// Head sampler should sample all traces, spans of unsampled trace
+	// never reach tail sampler.
+	//
+	//	Provider.AddTracerExporter(tracer.NewTailSampler(tracer_jaeger.New(),
+	//	    tracer.WithTailPolicies(tracer.ErrorPolicy(), tracer.LatencyPolicy(time.Second)),
+	//	))
+	TailSampler interface {
+		TracerExporter
+
+		// Flush
+		// decide and forward all buffered traces.
+		Flush() error
+
+		// Stats
+		// returns counters of sampler.
+		Stats() TailSamplerStats
+	}
+
+	// TailSamplerStats
+	// counters of tail sampler.
+	TailSamplerStats struct {
+		// BufferedSpans, BufferedTraces
+		// count of spans and traces waiting for decision.
+		BufferedSpans, BufferedTraces int
+
+		// SampledTraces, DroppedTraces
+		// count of traces kept or discarded by policies.
+		SampledTraces, DroppedTraces uint64
+
+		// EvictedSpans, EvictedTraces
+		// count of spans and traces decided before completed as
+		// memory limits exceeded.
+		EvictedSpans, EvictedTraces uint64
+
+		// LateSpans
+		// count of spans ended after decision of their trace, late
+		// spans follow the decision.
+		LateSpans uint64
+	}
+
+	// TailOption
+	// option of tail sampler.
+	TailOption func(o *tailSampler)
+
+	tailSampler struct {
+		sync.Mutex
+
+		exporter TracerExporter
+		policies []TailPolicy
+		running  bool
+
+		maxSpans, maxTraces int
+		wait                time.Duration
+
+		order  *list.List
+		spans  int
+		traces map[string]*list.Element
+
+		// decided
+		// decisions of recent traces, for late spans.
+		decided      map[string]bool
+		decidedOrder []string
+		decidedNext  int
+
+		stats TailSamplerStats
+	}
+
+	tailTrace struct {
+		id    string
+		first time.Time
+		spans []Span
+	}
+
+	attributePolicy struct {
+		key    string
+		values []interface{}
+	}
+
+	errorPolicy struct{}
+
+	latencyPolicy struct {
+		threshold time.Duration
+	}
+
+	probabilisticPolicy struct {
+		sampler Sampler
+	}
+)
+
+// NewTailSampler
+// returns a tail sampler forward kept traces to exporter. Traces kept
+// by probabilistic policy of ratio 1 if no policy specified.
+func NewTailSampler(exporter TracerExporter, opts ...TailOption) TailSampler {
+	o := (&tailSampler{exporter: exporter}).init()
+	for _, opt := range opts {
+		opt(o)
+	}
+	if len(o.policies) == 0 {
+		o.policies = []TailPolicy{ProbabilisticPolicy(1)}
+	}
+	return o
+}
+
+// WithTailDecisionWait
+// use specified duration since first span before a trace is decided.
+func WithTailDecisionWait(d time.Duration) TailOption {
+	return func(o *tailSampler) { o.wait = d }
+}
+
+// WithTailMaxSpans
+// use specified maximum of buffered spans, oldest traces decided
+// early if exceeded. Ignored if n is not positive.
+func WithTailMaxSpans(n int) TailOption {
+	return func(o *tailSampler) {
+		if n > 0 {
+			o.maxSpans = n
+		}
+	}
+}
+
+// WithTailMaxTraces
+// use specified maximum of buffered traces, oldest traces decided
+// early if exceeded. Ignored if n is not positive.
+func WithTailMaxTraces(n int) TailOption {
+	return func(o *tailSampler) {
+		if n > 0 {
+			o.maxTraces = n
+		}
+	}
+}
+
+// WithTailPolicies
+// use specified policies, trace kept if any policy returns true.
+func WithTailPolicies(policies ...TailPolicy) TailOption {
+	return func(o *tailSampler) { o.policies = policies }
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Policies
+// /////////////////////////////////////////////////////////////////////////////
+
+// AttributePolicy
+// keep trace if any span has attribute of key with one of values, any
+// value matched if values not specified.
+func AttributePolicy(key string, values ...interface{}) TailPolicy {
+	return &attributePolicy{key: key, values: values}
+}
+
+// ErrorPolicy
+// keep trace if any span has error status. A span has error status if
+// attribute error is true, otel.status_code is ERROR, http status code
+// is 5xx, or any ERROR/FATAL log recorded on span.
+func ErrorPolicy() TailPolicy {
+	return errorPolicy{}
+}
+
+// LatencyPolicy
+// keep trace if duration of local root span, or duration between the
+// first start and the last end of spans, is greater than threshold.
+func LatencyPolicy(threshold time.Duration) TailPolicy {
+	return &latencyPolicy{threshold: threshold}
+}
+
+// ProbabilisticPolicy
+// keep ratio of traces, decision is based on trace id.
+func ProbabilisticPolicy(ratio float64) TailPolicy {
+	return &probabilisticPolicy{sampler: TraceIdRatio(ratio)}
+}
+
+func (o *attributePolicy) Evaluate(spans []Span) bool {
+	for _, sp := range spans {
+		v, ok := sp.GetAttr()[o.key]
+		if !ok {
+			continue
+		}
+		if len(o.values) == 0 {
+			return true
+		}
+		for _, x := range o.values {
+			if reflect.DeepEqual(v, x) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (o *attributePolicy) String() string {
+	return fmt.Sprintf("Attribute{%s:%v}", o.key, o.values)
+}
+
+func (errorPolicy) Evaluate(spans []Span) bool {
+	for _, sp := range spans {
+		attr := sp.GetAttr()
+		if v, ok := attr["error"].(bool); ok && v {
+			return true
+		}
+		if v, ok := attr["otel.status_code"].(string); ok && strings.EqualFold(v, "ERROR") {
+			return true
+		}
+		for _, k := range []string{"http.response.status_code", "http.status_code"} {
+			if n, ok := statusCodeOf(attr[k]); ok && n >= 500 {
+				return true
+			}
+		}
+		for _, x := range sp.GetLogs() {
+			if i := x.Level.Int(); i > config.Off.Int() && i <= config.Error.Int() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (errorPolicy) String() string { return "Error" }
+
+func (o *latencyPolicy) Evaluate(spans []Span) bool {
+	var start, end time.Time
+	for _, sp := range spans {
+		if isLocalRoot(sp) {
+			return sp.GetDuration() > o.threshold
+		}
+		if start.IsZero() || sp.GetStartTime().Before(start) {
+			start = sp.GetStartTime()
+		}
+		if sp.GetEndTime().After(end) {
+			end = sp.GetEndTime()
+		}
+	}
+	return end.Sub(start) > o.threshold
+}
+
+func (o *latencyPolicy) String() string {
+	return fmt.Sprintf("Latency{%s}", o.threshold)
+}
+
+func (o *probabilisticPolicy) Evaluate(spans []Span) bool {
+	return len(spans) > 0 && o.sampler.Sample(SamplingParameters{TraceId: spans[0].GetTraceId()})
+}
+
+func (o *probabilisticPolicy) String() string {
+	return fmt.Sprintf("Probabilistic{%s}", o.sampler)
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Tail sampler: interface
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *tailSampler) Flush() error {
+	o.Lock()
+	decisions := make([]*tailTrace, 0, o.order.Len())
+	for o.order.Len() > 0 {
+		decisions = append(decisions, o.remove(o.order.Front()))
+	}
+	o.Unlock()
+
+	return o.forward(decisions...)
+}
+
+// Push
+// buffer span, trace decided if all spans of trace ended, late span
+// follow decision of its trace.
+func (o *tailSampler) Push(span Span) error {
+	var (
+		decisions []*tailTrace
+		id        = span.GetTraceId().String()
+	)
+
+	o.Lock()
+
+	// Late span
+	// follow decision.
+	if keep, ok := o.decided[id]; ok {
+		o.stats.LateSpans++
+		o.Unlock()
+		if keep {
+			return o.exporter.Push(span)
+		}
+		return nil
+	}
+
+	e, ok := o.traces[id]
+	if !ok {
+		e = o.order.PushBack(&tailTrace{id: id, first: time.Now()})
+		o.traces[id] = e
+	}
+
+	t := e.Value.(*tailTrace)
+	t.spans = append(t.spans, span)
+	o.spans++
+
+	// Completed
+	// if no span of trace is open.
+	if isCompleted(span) {
+		decisions = append(decisions, o.remove(e))
+	}
+
+	// Evict
+	// oldest traces if memory limits exceeded.
+	for o.order.Len() > 0 && (o.order.Len() > o.maxTraces || o.spans > o.maxSpans) {
+		x := o.remove(o.order.Front())
+		o.stats.EvictedSpans += uint64(len(x.spans))
+		o.stats.EvictedTraces++
+		decisions = append(decisions, x)
+	}
+
+	o.Unlock()
+	return o.forward(decisions...)
+}
+
+// Start
+// start wrapped exporter, decide timed out traces until ctx done.
+// Buffered traces are decided when ctx done, context of wrapped
+// exporter is cancelled after kept spans forwarded. Errors reported
+// as logs of provider which started sampler.
+func (o *tailSampler) Start(ctx context.Context) error {
+	o.Lock()
+	o.running = true
+	o.Unlock()
+
+	defer func() {
+		o.Lock()
+		o.running = false
+		o.Unlock()
+	}()
+
+	var (
+		p            = ProviderFromContext(ctx)
+		ectx, cancel = context.WithCancel(ContextWithProvider(context.Background(), p))
+	)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- o.exporter.Start(ectx) }()
+
+	interval := o.wait / 10
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := o.Flush(); err != nil {
+				o.report(p, err)
+			}
+			cancel()
+			return <-done
+		case <-ticker.C:
+			if err := o.expire(); err != nil {
+				o.report(p, err)
+			}
+		}
+	}
+}
+
+func (o *tailSampler) Stats() TailSamplerStats {
+	o.Lock()
+	defer o.Unlock()
+
+	s := o.stats
+	s.BufferedSpans = o.spans
+	s.BufferedTraces = o.order.Len()
+	return s
+}
+
+func (o *tailSampler) Stopped() bool {
+	o.Lock()
+	defer o.Unlock()
+	return !o.running && o.exporter.Stopped()
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Tail sampler: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// decide
+// returns true if any policy keep spans.
+func (o *tailSampler) decide(spans []Span) bool {
+	for _, p := range o.policies {
+		if p.Evaluate(spans) {
+			return true
+		}
+	}
+	return false
+}
+
+// expire
+// decide traces buffered longer than decision wait.
+func (o *tailSampler) expire() error {
+	var (
+		decisions []*tailTrace
+		deadline  = time.Now().Add(-o.wait)
+	)
+
+	o.Lock()
+	for o.order.Len() > 0 {
+		e := o.order.Front()
+		if e.Value.(*tailTrace).first.After(deadline) {
+			break
+		}
+		decisions = append(decisions, o.remove(e))
+	}
+	o.Unlock()
+
+	return o.forward(decisions...)
+}
+
+// forward
+// evaluate policies and push spans of kept traces to exporter, the
+// first error returned.
+func (o *tailSampler) forward(traces ...*tailTrace) (err error) {
+	for _, t := range traces {
+		keep := o.decide(t.spans)
+
+		o.Lock()
+		o.remember(t.id, keep)
+		if keep {
+			o.stats.SampledTraces++
+		} else {
+			o.stats.DroppedTraces++
+		}
+		o.Unlock()
+
+		if !keep {
+			continue
+		}
+		for _, sp := range t.spans {
+			if pe := o.exporter.Push(sp); pe != nil && err == nil {
+				err = pe
+			}
+		}
+	}
+	return
+}
+
+func (o *tailSampler) init() *tailSampler {
+	o.decided = make(map[string]bool)
+	o.maxSpans = DefaultTailMaxSpans
+	o.maxTraces = DefaultTailMaxTraces
+	o.order = list.New()
+	o.traces = make(map[string]*list.Element)
+	o.wait = DefaultTailDecisionWait
+	return o
+}
+
+// remember
+// record decision of trace, the oldest decision is forgotten if count
+// of decisions reached max traces.
+func (o *tailSampler) remember(id string, keep bool) {
+	if o.decidedOrder == nil {
+		o.decidedOrder = make([]string, o.maxTraces)
+	}
+
+	if old := o.decidedOrder[o.decidedNext]; old != "" {
+		delete(o.decided, old)
+	}
+	o.decided[id] = keep
+	o.decidedOrder[o.decidedNext] = id
+	o.decidedNext = (o.decidedNext + 1) % len(o.decidedOrder)
+}
+
+// remove
+// returns buffered trace removed from sampler, caller must hold lock.
+func (o *tailSampler) remove(e *list.Element) *tailTrace {
+	t := o.order.Remove(e).(*tailTrace)
+	delete(o.traces, t.id)
+	o.spans -= len(t.spans)
+	return t
+}
+
+func (o *tailSampler) report(p ProviderManager, err error) {
+	p.PushBaseLog(config.Error, "tail sampler: %v", err)
+}
+
+// isCompleted
+// return true if all spans created on trace ended, local root span
+// ended if trace is not counted.
+func isCompleted(sp Span) bool {
+	if tr, ok := sp.GetTrace().(*trace); ok {
+		return tr.openSpans() == 0
+	}
+	return isLocalRoot(sp)
+}
+
+// isLocalRoot
+// return true if span is the first span of trace in this process.
+func isLocalRoot(sp Span) bool {
+	return sp.GetParentSpanId().String() == sp.GetTrace().GetSpanId().String()
+}
+
+// statusCodeOf
+// returns status code of attribute value, integer or numeric string.
+func statusCodeOf(v interface{}) (int, bool) {
+	switch x := v.(type) {
+	case int:
+		return x, true
+	case int32:
+		return int(x), true
+	case int64:
+		return int(x), true
+	case string:
+		n, err := strconv.Atoi(x)
+		return n, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,281 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracer
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// testStopExporter
+// records count of spans received when context cancelled.
+type testStopExporter struct {
+	testTracerExporter
+	stopped int
+}
+
+func (o *testTracerExporter) count() int {
+	o.Lock()
+	defer o.Unlock()
+	return len(o.spans)
+}
+
+func (o *testStopExporter) Start(ctx context.Context) error {
+	<-ctx.Done()
+	o.stopped = o.count()
+	return nil
+}
+
+func TestTailSampler_Policies(t *testing.T) {
+	var (
+		e  = &testTracerExporter{}
+		p  = NewProvider()
+		ts = NewTailSampler(e, WithTailPolicies(ErrorPolicy(), LatencyPolicy(time.Hour)))
+	)
+
+	// Dropped
+	// no policy matched.
+	root := p.NewTrace("ok").NewSpan("root")
+	child := root.NewSpan("child")
+	child.End()
+	_ = ts.Push(child)
+	if e.count() != 0 || ts.Stats().BufferedSpans != 1 {
+		t.Fatalf("expect child buffered until root ended")
+	}
+
+	root.End()
+	_ = ts.Push(root)
+	if e.count() != 0 || ts.Stats().DroppedTraces != 1 {
+		t.Fatalf("expect trace dropped, stats: %+v", ts.Stats())
+	}
+
+	// Sampled
+	// error status on child.
+	root = p.NewTrace("failed").NewSpan("root")
+	child = root.NewSpan("child")
+	child.SetAttr("http.status_code", 503).End()
+	_ = ts.Push(child)
+	root.End()
+	_ = ts.Push(root)
+	if e.count() != 2 {
+		t.Fatalf("expect error trace forwarded, got %d spans", e.count())
+	}
+
+	// Late span
+	// follow decision.
+	late := root.NewSpan("late")
+	late.End()
+	_ = ts.Push(late)
+	if s := ts.Stats(); e.count() != 3 || s.LateSpans != 1 || s.SampledTraces != 1 || s.BufferedTraces != 0 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestTailSampler_Siblings(t *testing.T) {
+	var (
+		e  = &testTracerExporter{}
+		p  = NewProvider()
+		ts = NewTailSampler(e, WithTailPolicies(ErrorPolicy()))
+		tr = p.NewTrace("siblings")
+	)
+
+	// Siblings
+	// are local roots, trace completed after both ended.
+	a := tr.NewSpan("a")
+	b := tr.NewSpan("b")
+	a.End()
+	_ = ts.Push(a)
+	if s := ts.Stats(); s.BufferedSpans != 1 || s.DroppedTraces != 0 {
+		t.Fatalf("expect trace buffered until sibling ended, stats: %+v", s)
+	}
+
+	b.SetAttr("error", true).End()
+	_ = ts.Push(b)
+	if s := ts.Stats(); e.count() != 2 || s.SampledTraces != 1 || s.BufferedTraces != 0 {
+		t.Fatalf("expect trace kept by error of sibling, stats: %+v", s)
+	}
+}
+
+func TestTailSampler_Filtered(t *testing.T) {
+	var (
+		e  = &testTracerExporter{}
+		p  = NewProvider()
+		ts = NewTailSampler(e)
+	)
+
+	p.AddTracerExporter(ts, WithSpanFilter(func(span Span) bool { return span.GetName() != "drop" }))
+
+	// Filtered
+	// span never reach sampler, trace completed when root ended.
+	root := p.NewTrace("filtered").NewSpan("root")
+	child := root.NewSpan("drop")
+	child.End()
+	root.End()
+	if s := ts.Stats(); s.SampledTraces != 1 || s.BufferedTraces != 0 || e.count() != 1 {
+		t.Fatalf("expect trace decided without filtered span, stats: %+v", s)
+	}
+
+	// Ended twice
+	// counted once, trace completed after all spans ended.
+	root = p.NewTrace("twice").NewSpan("root")
+	child = root.NewSpan("child")
+	child.End()
+	child.End()
+	if s := ts.Stats(); s.BufferedSpans != 1 || s.SampledTraces != 1 {
+		t.Fatalf("expect trace buffered until root ended, stats: %+v", s)
+	}
+
+	root.End()
+	if s := ts.Stats(); s.SampledTraces != 2 || s.BufferedTraces != 0 || e.count() != 3 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestTailSampler_Report(t *testing.T) {
+	var (
+		e    = &testTracerExporter{}
+		logs = &testLoggerExporter{}
+		p    = (&provider{}).init()
+		ts   = NewTailSampler(e, WithTailDecisionWait(20*time.Millisecond))
+	)
+
+	p.AddLoggerExporter(logs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- ts.Start(ContextWithProvider(ctx, p)) }()
+
+	sp := p.NewTrace("report").NewSpan("root").NewSpan("child")
+	sp.End()
+	_ = ts.Push(sp)
+
+	// Reported
+	// as log of provider which started sampler.
+	for deadline := time.Now().Add(time.Second); logs.count() == 0; {
+		if time.Now().After(deadline) {
+			t.Fatalf("expect forward error reported on provider")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestTailSampler_Limits(t *testing.T) {
+	ts := NewTailSampler(&testTracerExporter{}, WithTailMaxTraces(0), WithTailMaxSpans(-1)).(*tailSampler)
+	if ts.maxTraces != DefaultTailMaxTraces || ts.maxSpans != DefaultTailMaxSpans {
+		t.Fatalf("expect defaults kept, got %d, %d", ts.maxTraces, ts.maxSpans)
+	}
+
+	sp := NewProvider().NewTrace("limits").NewSpan("root")
+	sp.End()
+	_ = ts.Push(sp)
+	if s := ts.Stats(); s.SampledTraces != 1 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestTailSampler_Evict(t *testing.T) {
+	var (
+		e  = &testTracerExporter{}
+		p  = NewProvider()
+		ts = NewTailSampler(e, WithTailMaxTraces(2), WithTailPolicies(AttributePolicy("keep", true)))
+	)
+
+	for i := 0; i < 3; i++ {
+		sp := p.NewTrace("evict").NewSpan("root").NewSpan("child")
+		sp.SetAttr("keep", i == 0).End()
+		_ = ts.Push(sp)
+	}
+
+	if s := ts.Stats(); s.EvictedTraces != 1 || s.EvictedSpans != 1 || s.BufferedTraces != 2 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+	if e.count() != 1 {
+		t.Fatalf("expect evicted trace decided by policies")
+	}
+
+	_ = ts.Flush()
+	if s := ts.Stats(); s.BufferedTraces != 0 || s.DroppedTraces != 2 {
+		t.Fatalf("unexpected stats after flush: %+v", s)
+	}
+}
+
+func TestTailSampler_Timeout(t *testing.T) {
+	var (
+		e  = &testTracerExporter{}
+		ts = NewTailSampler(e, WithTailDecisionWait(20*time.Millisecond))
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- ts.Start(ctx) }()
+
+	sp := NewProvider().NewTrace("timeout").NewSpan("root").NewSpan("child")
+	sp.End()
+	_ = ts.Push(sp)
+
+	for deadline := time.Now().Add(time.Second); e.count() == 0; {
+		if time.Now().After(deadline) {
+			t.Fatalf("expect trace decided after decision wait")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	cancel()
+	<-done
+	if !ts.Stopped() {
+		t.Errorf("expect stopped")
+	}
+}
+
+func TestLatencyPolicy(t *testing.T) {
+	root := NewProvider().NewTrace("slow").NewSpan("root")
+	time.Sleep(5 * time.Millisecond)
+	root.End()
+
+	if !LatencyPolicy(time.Millisecond).Evaluate([]Span{root}) {
+		t.Errorf("expect slow root matched")
+	}
+	if LatencyPolicy(time.Minute).Evaluate([]Span{root}) {
+		t.Errorf("expect fast root not matched")
+	}
+}
+
+func TestTailSampler_Stop(t *testing.T) {
+	var (
+		e  = &testStopExporter{}
+		ts = NewTailSampler(e)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- ts.Start(ctx) }()
+
+	// Flushed
+	// before context of wrapped exporter cancelled.
+	sp := NewProvider().NewTrace("stop").NewSpan("root").NewSpan("child")
+	sp.End()
+	_ = ts.Push(sp)
+
+	cancel()
+	<-done
+	if e.stopped != 1 {
+		t.Errorf("expect buffered span forwarded before exporter stopped, got %d", e.stopped)
+	}
+}
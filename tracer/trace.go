@@ -18,8 +18,8 @@ package tracer
 import (
 	"context"
 	"encoding/hex"
-	"github.com/fuyibing/log/config"
 	"net/http"
+	"sync/atomic"
 )
 
 type (
@@ -48,6 +48,22 @@ type (
 		provider ProviderManager
 		spanId   SpanId
 		traceId  TraceId
+
+		// debug, sampled, state
+		// sampling flags and vendor specific tracestate, received
+		// from upstream or decided by sampler of provider.
+		debug   bool
+		sampled bool
+		state   string
+
+		// parent
+		// remote trace context, zero value for root trace.
+		parent SpanContext
+
+		// spans, ended
+		// count of spans created and ended on trace, accessed
+		// atomically.
+		spans, ended int32
 	}
 
 	traceNewer interface {
@@ -58,10 +74,13 @@ type (
 	traceGetter interface {
 		GetAttr() Attr
 		GetContext() context.Context
+		GetDebug() bool
 		GetName() string
 		GetProvider() ProviderManager
+		GetSampled() bool
 		GetSpanId() SpanId
 		GetTraceId() TraceId
+		GetTraceState() string
 	}
 
 	traceSetter interface {
@@ -78,19 +97,29 @@ type (
 func (o *trace) NewSpan(name string) Span {
 	v := (&span{}).init(name)
 	v.attr.Copy(o.attr)
+	v.ctx = ContextWithSpan(o.ctx, v)
 	v.parentSpanId = o.spanId
 	v.trace = o
+	v.useBaggage()
+	o.addSpan()
 	return v
 }
 
 // NewSpanWithContext
 // returns a span which created from a trace and with specified context.
+// Span is a child of span stored in ctx if it belongs to this trace.
 func (o *trace) NewSpanWithContext(ctx context.Context, name string) Span {
 	v := (&span{}).init(name)
 	v.attr.Copy(o.attr)
-	v.ctx = ctx
+	v.ctx = ContextWithSpan(ctx, v)
 	v.parentSpanId = o.spanId
 	v.trace = o
+	v.useBaggage()
+	o.addSpan()
+
+	if p, ok := SpanFromContext(ctx).(*span); ok && p.trace == o {
+		v.parentSpanId = p.spanId
+	}
 	return v
 }
 
@@ -100,10 +129,13 @@ func (o *trace) NewSpanWithContext(ctx context.Context, name string) Span {
 
 func (o *trace) GetAttr() Attr                { return o.attr }
 func (o *trace) GetContext() context.Context  { return o.ctx }
+func (o *trace) GetDebug() bool               { return o.debug }
 func (o *trace) GetName() string              { return o.name }
 func (o *trace) GetProvider() ProviderManager { return o.provider }
+func (o *trace) GetSampled() bool             { return o.sampled }
 func (o *trace) GetSpanId() SpanId            { return o.spanId }
 func (o *trace) GetTraceId() TraceId          { return o.traceId }
+func (o *trace) GetTraceState() string        { return o.state }
 
 // /////////////////////////////////////////////////////////////////////////////
 // Trace: setter
@@ -120,6 +152,7 @@ func (o *trace) SetAttr(key string, value interface{}) Trace {
 
 func (o TraceId) Byte() []byte   { return o.bs[:] }
 func (o TraceId) Err() error     { return o.err }
+func (o TraceId) IsZero() bool   { return o.bs == [16]byte{} }
 func (o TraceId) Security() bool { return o.security }
 
 func (o TraceId) String() string {
@@ -133,31 +166,54 @@ func (o TraceId) String() string {
 // Trace: access
 // /////////////////////////////////////////////////////////////////////////////
 
+// addSpan
+// count a span created on trace.
+func (o *trace) addSpan() {
+	atomic.AddInt32(&o.spans, 1)
+}
+
+// endSpan
+// count a span ended on trace.
+func (o *trace) endSpan() {
+	atomic.AddInt32(&o.ended, 1)
+}
+
 func (o *trace) init() *trace {
 	return o
 }
 
-func (o *trace) useRequest(req *http.Request) {
-	var (
-		sid = req.Header.Get(config.Config.GetOpenTracingSpanId())
-		tid = req.Header.Get(config.Config.GetOpenTracingTraceId())
-	)
+// openSpans
+// returns count of spans created on trace and not ended yet.
+func (o *trace) openSpans() int {
+	return int(atomic.LoadInt32(&o.spans) - atomic.LoadInt32(&o.ended))
+}
 
+func (o *trace) useRequest(req *http.Request) {
 	o.attr.Add("http.header", req.Header)
 	o.attr.Add("http.request.url", req.RequestURI)
 	o.attr.Add("http.request.method", req.Method)
 	o.attr.Add("http.request.protocol", req.Proto)
 	o.attr.Add("http.user.agent", req.UserAgent())
 
-	if tid != "" && sid != "" {
-		if o.spanId = Identify.HexSpanId(sid); o.spanId.Err() != nil {
-		}
-
-		if o.traceId = Identify.HexTraceId(tid); o.traceId.Err() != nil {
-		}
+	// Remote
+	// trace context extracted by propagator, root if not found or
+	// invalid.
+	o.ctx = GetPropagator().Extract(o.ctx, HeaderCarrier(req.Header))
+	if sc, ok := RemoteSpanContextFromContext(o.ctx); ok && sc.IsValid() {
+		o.useRemote(sc)
+	} else {
+		o.useRoot()
 	}
 }
 
+func (o *trace) useRemote(sc SpanContext) {
+	o.debug = sc.Debug
+	o.parent = sc
+	o.spanId = sc.SpanId
+	o.state = sc.TraceState
+	o.traceId = sc.TraceId
+}
+
 func (o *trace) useRoot() {
 	o.spanId = Identify.NewEmptySpanId()
 	o.traceId = Identify.NewTraceId()
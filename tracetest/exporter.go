@@ -0,0 +1,195 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+// Package tracetest
+// in-memory exporters and assertions for testing instrumentation.
+//
+//	func TestHandler(t *testing.T) {
+//	    r := tracetest.New(t)
+//	    tr := r.NewTrace("request")
+//	    handle(tr)
+//	    span := r.AssertSpan("db.query")
+//	    r.AssertAttr(span, "db.table", "users")
+//	}
+package tracetest
+
+import (
+	"context"
+	"fmt"
+	"github.com/fuyibing/log/tracer"
+	"sync"
+	"testing"
+	"time"
+)
+
+type (
+	// LoggerExporter
+	// records pushed logs in memory, logs routed to testing.TB if
+	// attached.
+	LoggerExporter struct {
+		sync.Mutex
+
+		logs []*tracer.Log
+		t    testing.TB
+	}
+
+	// TracerExporter
+	// records pushed spans in memory.
+	TracerExporter struct {
+		sync.Mutex
+
+		notify chan bool
+		spans  []tracer.Span
+	}
+)
+
+// NewLoggerExporter
+// returns an in-memory logger exporter.
+func NewLoggerExporter() *LoggerExporter {
+	return &LoggerExporter{}
+}
+
+// NewTracerExporter
+// returns an in-memory tracer exporter.
+func NewTracerExporter() *TracerExporter {
+	return &TracerExporter{notify: make(chan bool, 1)}
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Logger exporter
+// /////////////////////////////////////////////////////////////////////////////
+
+// Attach
+// route logs to t.Log, detached when test finished.
+func (o *LoggerExporter) Attach(t testing.TB) {
+	o.Lock()
+	o.t = t
+	o.Unlock()
+
+	t.Cleanup(func() {
+		o.Lock()
+		if o.t == t {
+			o.t = nil
+		}
+		o.Unlock()
+	})
+}
+
+// Logs
+// returns a copy of recorded logs.
+func (o *LoggerExporter) Logs() []*tracer.Log {
+	o.Lock()
+	defer o.Unlock()
+	return append([]*tracer.Log(nil), o.logs...)
+}
+
+func (o *LoggerExporter) Push(log *tracer.Log) error {
+	o.Lock()
+	defer o.Unlock()
+
+	o.logs = append(o.logs, log)
+	if o.t != nil {
+		o.t.Log(o.format(log))
+	}
+	return nil
+}
+
+// Reset
+// remove recorded logs.
+func (o *LoggerExporter) Reset() {
+	o.Lock()
+	o.logs = nil
+	o.Unlock()
+}
+
+func (o *LoggerExporter) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (o *LoggerExporter) Stopped() bool { return true }
+
+func (o *LoggerExporter) format(log *tracer.Log) string {
+	s := fmt.Sprintf("[%s] %s", log.Level, log.Text)
+	if !log.TraceId.IsZero() {
+		s += fmt.Sprintf(" (trace_id=%s span_id=%s)", log.TraceId, log.SpanId)
+	}
+	if len(log.Fields) > 0 {
+		s += fmt.Sprintf(" %v", log.Fields.JSON())
+	}
+	return s
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Tracer exporter
+// /////////////////////////////////////////////////////////////////////////////
+
+func (o *TracerExporter) Push(span tracer.Span) error {
+	o.Lock()
+	o.spans = append(o.spans, span)
+	o.Unlock()
+
+	select {
+	case o.notify <- true:
+	default:
+	}
+	return nil
+}
+
+// Reset
+// remove recorded spans.
+func (o *TracerExporter) Reset() {
+	o.Lock()
+	o.spans = nil
+	o.Unlock()
+}
+
+// Spans
+// returns a copy of recorded spans, in order of end.
+func (o *TracerExporter) Spans() []tracer.Span {
+	o.Lock()
+	defer o.Unlock()
+	return append([]tracer.Span(nil), o.spans...)
+}
+
+func (o *TracerExporter) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (o *TracerExporter) Stopped() bool { return true }
+
+// WaitSpans
+// wait until n spans recorded, returns an error if timeout.
+func (o *TracerExporter) WaitSpans(n int, timeout time.Duration) ([]tracer.Span, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		if list := o.Spans(); len(list) >= n {
+			return list, nil
+		}
+
+		select {
+		case <-o.notify:
+		case <-timer.C:
+			list := o.Spans()
+			if len(list) >= n {
+				return list, nil
+			}
+			return list, fmt.Errorf("expect %d spans in %s, got %d", n, timeout, len(list))
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracetest
+
+import (
+	"github.com/fuyibing/log/config"
+	"github.com/fuyibing/log/tracer"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type (
+	// Recorder
+	// an isolated provider with in-memory exporters, bound to a test.
+	Recorder struct {
+		Logger   *LoggerExporter
+		Provider tracer.ProviderManager
+		Tracer   *TracerExporter
+
+		t testing.TB
+	}
+)
+
+// New
+// returns a recorder with isolated provider, captured logs routed to
+// t.Log. Traces must be created with recorder (or it's provider), traces
+// of global Provider are not recorded.
+func New(t testing.TB) *Recorder {
+	o := &Recorder{
+		Logger:   NewLoggerExporter(),
+		Provider: tracer.NewProvider(),
+		Tracer:   NewTracerExporter(),
+		t:        t,
+	}
+
+	o.Logger.Attach(t)
+	o.Provider.AddLoggerExporter(o.Logger)
+	o.Provider.AddTracerExporter(o.Tracer)
+	return o
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Recorder: access
+// /////////////////////////////////////////////////////////////////////////////
+
+// FindSpan
+// returns the first recorded span with given name, return nil if not
+// found.
+func (o *Recorder) FindSpan(name string) tracer.Span {
+	for _, s := range o.Tracer.Spans() {
+		if s.GetName() == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// FindSpans
+// returns recorded spans with given name.
+func (o *Recorder) FindSpans(name string) (list []tracer.Span) {
+	for _, s := range o.Tracer.Spans() {
+		if s.GetName() == name {
+			list = append(list, s)
+		}
+	}
+	return
+}
+
+// Logs
+// returns recorded logs.
+func (o *Recorder) Logs() []*tracer.Log { return o.Logger.Logs() }
+
+// LogsOf
+// returns recorded logs sent on given span.
+func (o *Recorder) LogsOf(span tracer.Span) (list []*tracer.Log) {
+	for _, x := range o.Logger.Logs() {
+		if x.SpanId.String() == span.GetSpanId().String() && x.TraceId.String() == span.GetTraceId().String() {
+			list = append(list, x)
+		}
+	}
+	return
+}
+
+// NewTrace
+// returns a trace of isolated provider.
+func (o *Recorder) NewTrace(name string) tracer.Trace { return o.Provider.NewTrace(name) }
+
+// Reset
+// remove recorded logs and spans.
+func (o *Recorder) Reset() {
+	o.Logger.Reset()
+	o.Tracer.Reset()
+}
+
+// Spans
+// returns recorded spans, in order of end.
+func (o *Recorder) Spans() []tracer.Span { return o.Tracer.Spans() }
+
+// WaitSpans
+// wait until n spans recorded, test failed if timeout.
+func (o *Recorder) WaitSpans(n int, timeout time.Duration) []tracer.Span {
+	o.t.Helper()
+
+	list, err := o.Tracer.WaitSpans(n, timeout)
+	if err != nil {
+		o.t.Errorf("tracetest: %v", err)
+	}
+	return list
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+// Recorder: assertion
+// /////////////////////////////////////////////////////////////////////////////
+
+// AssertAttr
+// assert span has an attribute with equal value.
+func (o *Recorder) AssertAttr(span tracer.Span, key string, value interface{}) bool {
+	o.t.Helper()
+
+	v, ok := span.GetAttr()[key]
+	if !ok {
+		o.t.Errorf("tracetest: span %q has no attribute %q", span.GetName(), key)
+		return false
+	}
+	if !reflect.DeepEqual(v, value) {
+		o.t.Errorf("tracetest: span %q attribute %q: expect %#v, got %#v", span.GetName(), key, value, v)
+		return false
+	}
+	return true
+}
+
+// AssertLog
+// assert a log with level and text contains substring was sent on
+// span, nil span matches any log.
+func (o *Recorder) AssertLog(span tracer.Span, level config.LoggerLevel, text string) bool {
+	o.t.Helper()
+
+	list, name := o.Logs(), "provider"
+	if span != nil {
+		list, name = o.LogsOf(span), span.GetName()
+	}
+
+	for _, x := range list {
+		if x.Level == level && strings.Contains(x.Text, text) {
+			return true
+		}
+	}
+	o.t.Errorf("tracetest: %s log contains %q not sent on %s", level, text, name)
+	return false
+}
+
+// AssertParent
+// assert child is a direct child of parent in same trace.
+func (o *Recorder) AssertParent(parent, child tracer.Span) bool {
+	o.t.Helper()
+
+	if parent.GetTraceId().String() != child.GetTraceId().String() {
+		o.t.Errorf("tracetest: span %q and %q are in different traces", parent.GetName(), child.GetName())
+		return false
+	}
+	if child.GetParentSpanId().String() != parent.GetSpanId().String() {
+		o.t.Errorf("tracetest: span %q is not a child of %q", child.GetName(), parent.GetName())
+		return false
+	}
+	return true
+}
+
+// AssertSpan
+// returns the first recorded span with given name, test failed if not
+// found.
+func (o *Recorder) AssertSpan(name string) tracer.Span {
+	o.t.Helper()
+
+	if s := o.FindSpan(name); s != nil {
+		return s
+	}
+	o.t.Errorf("tracetest: span %q not recorded", name)
+	return nil
+}
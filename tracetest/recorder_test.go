@@ -0,0 +1,115 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// author: wsfuyibing <websearch@163.com>
+// date: 2026-10-18
+
+package tracetest
+
+import (
+	"fmt"
+	"github.com/fuyibing/log/config"
+	"testing"
+	"time"
+)
+
+// fakeT
+// records failures instead of failing the test.
+type fakeT struct {
+	*testing.T
+	errors []string
+}
+
+func (o *fakeT) Errorf(format string, args ...interface{}) {
+	o.errors = append(o.errors, fmt.Sprintf(format, args...))
+}
+
+func (o *fakeT) Helper() {}
+
+func TestRecorder(t *testing.T) {
+	r := New(t)
+
+	tr := r.NewTrace("request")
+	parent := tr.NewSpan("handler")
+	child := parent.NewSpan("db.query")
+	child.SetAttr("db.table", "users")
+	child.Info("query %s", "users")
+	child.End()
+	parent.End()
+
+	if n := len(r.WaitSpans(2, time.Second)); n != 2 {
+		t.Fatalf("expect 2 spans, got %d", n)
+	}
+
+	p, c := r.AssertSpan("handler"), r.AssertSpan("db.query")
+	r.AssertParent(p, c)
+	r.AssertAttr(c, "db.table", "users")
+	r.AssertLog(c, config.Info, "query users")
+
+	if n := len(r.LogsOf(p)); n != 0 {
+		t.Errorf("expect no logs on parent, got %d", n)
+	}
+}
+
+func TestRecorderFailures(t *testing.T) {
+	ft := &fakeT{T: t}
+	r := New(ft)
+
+	tr := r.NewTrace("request")
+	a := tr.NewSpan("a")
+	a.SetAttr("k", 1)
+	a.End()
+	b := r.NewTrace("other").NewSpan("b")
+	b.End()
+
+	r.AssertSpan("missing")
+	r.AssertParent(a, b)
+	r.AssertAttr(a, "k", 2)
+	r.AssertAttr(a, "x", 1)
+	r.AssertLog(a, config.Error, "boom")
+	r.WaitSpans(3, 10*time.Millisecond)
+
+	if len(ft.errors) != 6 {
+		t.Errorf("expect 6 failures, got %d: %v", len(ft.errors), ft.errors)
+	}
+}
+
+func TestRecorderIsolated(t *testing.T) {
+	r1, r2 := New(t), New(t)
+
+	r1.NewTrace("one").NewSpan("s1").End()
+	r2.NewTrace("two").NewSpan("s2").End()
+
+	if r1.FindSpan("s2") != nil || r2.FindSpan("s1") != nil {
+		t.Errorf("spans leaked between recorders")
+	}
+
+	r1.Reset()
+	if len(r1.Spans()) != 0 {
+		t.Errorf("expect no spans after reset")
+	}
+}
+
+func TestTracerExporterWait(t *testing.T) {
+	e := NewTracerExporter()
+	r := New(t)
+	r.Provider.AddTracerExporter(e)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		r.NewTrace("async").NewSpan("late").End()
+	}()
+
+	if _, err := e.WaitSpans(1, time.Second); err != nil {
+		t.Errorf("wait spans: %v", err)
+	}
+}